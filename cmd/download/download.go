@@ -8,10 +8,20 @@ package download
 import (
 	"os"
 
+	"github.com/nicholas-fedor/goUpdater/internal/cli"
 	"github.com/nicholas-fedor/goUpdater/internal/download"
+	"github.com/nicholas-fedor/goUpdater/internal/logger"
 	"github.com/spf13/cobra"
 )
 
+// result is the --json output of the download command.
+// Algorithm names the hash of Checksum, "sha256" or "sha512", as published for the release.
+type result struct {
+	ArchivePath string `json:"archivePath"`
+	Algorithm   string `json:"algorithm"`
+	Checksum    string `json:"checksum"`
+}
+
 // NewDownloadCmd creates the download command.
 func NewDownloadCmd() *cobra.Command {
 	return &cobra.Command{
@@ -35,9 +45,24 @@ The archive will be downloaded to a temporary directory and verified with its ch
 		PersistentPreRunE:      nil,
 		PreRun:                 nil,
 		PreRunE:                nil,
-		Run: func(_ *cobra.Command, _ []string) {
-			_, _, err := download.Download()
+		Run: func(cmd *cobra.Command, _ []string) {
+			archivePath, checksum, err := download.Download()
+			if err != nil {
+				os.Exit(1)
+			}
+
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			if !jsonOutput {
+				return
+			}
+
+			err = cli.PrintJSON(os.Stdout, result{
+				ArchivePath: archivePath,
+				Algorithm:   checksum.Algorithm.String(),
+				Checksum:    checksum.Value,
+			})
 			if err != nil {
+				logger.Errorf("Error printing result: %v", err)
 				os.Exit(1)
 			}
 		},
@@ -6,10 +6,93 @@
 package install
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nicholas-fedor/goUpdater/internal/archive"
+	"github.com/nicholas-fedor/goUpdater/internal/cli"
+	"github.com/nicholas-fedor/goUpdater/internal/download"
 	"github.com/nicholas-fedor/goUpdater/internal/install"
+	"github.com/nicholas-fedor/goUpdater/internal/logger"
+	"github.com/nicholas-fedor/goUpdater/internal/privileges"
+	"github.com/nicholas-fedor/goUpdater/internal/update"
+	"github.com/nicholas-fedor/goUpdater/internal/verify"
 	"github.com/spf13/cobra"
 )
 
+// installReport describes the result of an install for --json by comparing the installed version
+// before and after the install started at started.
+func installReport(installDir, previousVersion string, started time.Time) *update.Report {
+	currentVersion, err := verify.GetInstalledVersion(installDir)
+	if err != nil {
+		logger.Debugf("Failed to read the installed Go version: %v", err)
+	}
+
+	action := update.ActionUpdated
+
+	switch previousVersion {
+	case "":
+		action = update.ActionInstalled
+	case currentVersion:
+		action = update.ActionSkipped
+	}
+
+	return &update.Report{
+		InstallDir:  installDir,
+		FromVersion: previousVersion,
+		ToVersion:   currentVersion,
+		Action:      action,
+		DurationMs:  time.Since(started).Milliseconds(),
+	}
+}
+
+// installVersioned installs targetVersion, or the latest release, below baseDir with install.Versioned.
+// The version directory is given to uid and gid when setOwner is set, as for --dest-owner, and otherwise
+// to the user who ran goUpdater through sudo or doas when that user owns baseDir.
+func installVersioned(baseDir, targetVersion string, excludes []string, setOwner bool, uid, gid int) error {
+	versionDir, err := install.Versioned(baseDir, targetVersion, excludes)
+	if err != nil {
+		return err
+	}
+
+	if !setOwner {
+		uid, gid, setOwner = install.InvokingOwner(versionDir)
+	}
+
+	if setOwner {
+		return install.ChownTree(versionDir, uid, gid)
+	}
+
+	return nil
+}
+
+// printReport prints the install report as JSON when jsonOutput is set.
+func printReport(jsonOutput bool, installDir, previousVersion string, started time.Time) {
+	if !jsonOutput {
+		return
+	}
+
+	err := cli.PrintJSON(os.Stdout, installReport(installDir, previousVersion, started))
+	if err != nil {
+		logger.Errorf("Error printing result: %v", err)
+		os.Exit(1)
+	}
+}
+
+// printError prints err as a JSON error object when jsonOutput is set, before the command exits with status 1.
+func printError(jsonOutput bool, err error) {
+	if !jsonOutput {
+		return
+	}
+
+	printErr := cli.PrintJSONError(os.Stdout, err)
+	if printErr != nil {
+		logger.Errorf("Error printing result: %v", printErr)
+	}
+}
+
 // createInstallCommand creates the cobra command with basic configuration.
 // It sets up the command structure, arguments, and flags for the install command.
 func createInstallCommand() *cobra.Command {
@@ -18,7 +101,11 @@ func createInstallCommand() *cobra.Command {
 		Short: "Install the latest Go version",
 		Long: `Install the latest Go version by downloading it and extracting to the installation directory.
 By default, Go is installed to /usr/local/go. If an archive path is provided,
-it will install from that archive instead.`,
+it will install from that archive instead. With --version, the given published Go version is installed,
+replacing the existing installation even if it is newer. With --base-dir, each version is installed in its
+own directory below the base directory and the current symlink there points at the newest install.
+With --checksum or --checksum-file, an archive given as argument is checked against its published SHA256
+checksum before it is installed; --checksum-algorithm sha512 selects a SHA512 checksum instead.`,
 		Aliases:                nil,
 		SuggestFor:             nil,
 		GroupID:                "",
@@ -61,6 +148,22 @@ it will install from that archive instead.`,
 	}
 
 	cmd.Flags().StringP("install-dir", "d", "/usr/local/go", "Directory to install Go")
+	cmd.Flags().Bool("slim", false,
+		"Skip prebuilt pkg/<os>_<arch> package archives (Go rebuilds them; the first build will be slower)")
+	cmd.Flags().String("dest-owner", "", "Change ownership of the installed tree to user[:group] after installation")
+	cmd.Flags().String("version", "",
+		"Install this published Go version (e.g. go1.21.13), downgrading the existing installation if needed")
+	cmd.Flags().String("base-dir", "",
+		"Install into a versioned directory below this directory, such as ~/sdk, and make it the current version")
+	cmd.Flags().String("checksum", "",
+		"Expected SHA256 checksum of the archive given as argument, verified before installing")
+	cmd.Flags().String("checksum-file", "",
+		"File holding the archive's SHA256 checksum, alone or as a sha256sum line naming the archive")
+	cmd.Flags().String("checksum-algorithm", string(download.ChecksumSHA256),
+		"Algorithm of the --checksum or --checksum-file checksum: sha256 or sha512")
+	cmd.MarkFlagsMutuallyExclusive("checksum", "checksum-file")
+	cmd.MarkFlagsMutuallyExclusive("version", "slim")
+	cmd.MarkFlagsMutuallyExclusive("base-dir", "install-dir")
 
 	return cmd
 }
@@ -71,17 +174,131 @@ func NewInstallCmd() *cobra.Command {
 
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		installDir, _ := cmd.Flags().GetString("install-dir")
+		destOwner, _ := cmd.Flags().GetString("dest-owner")
+		slim, _ := cmd.Flags().GetBool("slim")
+		targetVersion, _ := cmd.Flags().GetString("version")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		baseDir, _ := cmd.Flags().GetString("base-dir")
+		checksum, _ := cmd.Flags().GetString("checksum")
+		checksumFile, _ := cmd.Flags().GetString("checksum-file")
+		checksumAlgorithm, _ := cmd.Flags().GetString("checksum-algorithm")
+		started := time.Now()
+
+		if baseDir != "" {
+			installDir = filepath.Join(baseDir, install.CurrentLink)
+		}
+
+		previousVersion, err := verify.GetInstalledVersion(installDir)
+		if err != nil {
+			previousVersion = ""
+		}
+
+		var excludes []string
+		if slim {
+			excludes = archive.PrebuiltPackageExcludes()
+		}
 
 		var archivePath string
 		if len(args) > 0 {
 			archivePath = args[0]
 		}
 
-		err := install.Install(installDir, archivePath)
+		if (checksum != "" || checksumFile != "") && archivePath == "" {
+			logger.Error("--checksum and --checksum-file require an archive path; downloads are always verified")
+			os.Exit(1)
+		}
+
+		if checksumFile != "" {
+			checksum, err = download.ReadChecksumFile(checksumFile, filepath.Base(archivePath))
+			if err != nil {
+				logger.Errorf("Error reading --checksum-file: %v", err)
+				os.Exit(1)
+			}
+		}
+
+		algorithm, err := download.ParseChecksumAlgorithm(checksumAlgorithm)
 		if err != nil {
-			// Error handling is done within InstallGo, but we need to check the return value
+			logger.Errorf("Error parsing --checksum-algorithm: %v", err)
+			os.Exit(1)
+		}
+
+		var uid, gid int
+
+		if destOwner != "" {
+			uid, gid, err = install.ParseOwner(destOwner)
+			if err != nil {
+				logger.Errorf("Error resolving --dest-owner: %v", err)
+				os.Exit(1)
+			}
+		}
+
+		if baseDir != "" {
+			if archivePath != "" {
+				logger.Error("Cannot combine an archive path with --base-dir")
+				os.Exit(1)
+			}
+
+			err = privileges.ElevateIfRequired(baseDir, func() error {
+				return installVersioned(baseDir, targetVersion, excludes, destOwner != "", uid, gid)
+			})
+			if err != nil {
+				logger.Errorf("Error installing Go into %s: %v", baseDir, err)
+				printError(jsonOutput, err)
+				os.Exit(1)
+			}
+
+			install.LogPathGuidance(installDir, previousVersion == "")
+			printReport(jsonOutput, installDir, previousVersion, started)
+
 			return
 		}
+
+		if targetVersion != "" {
+			if archivePath != "" {
+				logger.Error("Cannot combine an archive path with --version")
+				os.Exit(1)
+			}
+
+			err = update.ToVersionWithPrivileges(installDir, targetVersion, true)
+			if errors.Is(err, update.ErrAlreadyUpToDate) {
+				logger.Info(err.Error())
+				printReport(jsonOutput, installDir, previousVersion, started)
+
+				return
+			}
+
+			if err != nil {
+				logger.Errorf("Error installing Go %s: %v", targetVersion, err)
+				printError(jsonOutput, err)
+				os.Exit(1)
+			}
+		} else {
+			expected := download.Checksum{Algorithm: algorithm, Value: checksum}
+
+			err = install.Install(installDir, archivePath, expected, excludes)
+			if err != nil {
+				// The error has already been logged by the privileged operation
+				printError(jsonOutput, err)
+				os.Exit(1)
+			}
+		}
+
+		if destOwner != "" {
+			err = install.ChownTree(installDir, uid, gid)
+			if err != nil {
+				logger.Errorf("Error applying --dest-owner: %v", err)
+				os.Exit(1)
+			}
+		} else if invokerUID, invokerGID, ok := install.InvokingOwner(installDir); ok {
+			err = install.ChownTree(installDir, invokerUID, invokerGID)
+			if err != nil {
+				logger.Errorf("Error returning ownership of %s to the invoking user: %v", installDir, err)
+				os.Exit(1)
+			}
+		}
+
+		install.LogPathGuidance(installDir, previousVersion == "")
+		printReport(jsonOutput, installDir, previousVersion, started)
 	}
 
 	return cmd
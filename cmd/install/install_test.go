@@ -118,6 +118,34 @@ func TestInstallCmdFlags(t *testing.T) {
 	}
 }
 
+func TestInstallCmdBaseDirExcludesInstallDir(t *testing.T) {
+	t.Parallel()
+
+	cmd := install.NewInstallCmd()
+	cmd.SetArgs([]string{"--base-dir", t.TempDir(), "--install-dir", t.TempDir()})
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Error("Expected an error combining --base-dir with --install-dir")
+	}
+}
+
+func TestInstallCmdChecksumFlagsExclusive(t *testing.T) {
+	t.Parallel()
+
+	cmd := install.NewInstallCmd()
+	cmd.SetArgs([]string{"--checksum", "abc", "--checksum-file", "SHA256SUMS", "go.tar.gz"})
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Error("Expected an error combining --checksum with --checksum-file")
+	}
+}
+
 func TestInstallCmdStructure(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,84 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package list provides the list command for goUpdater.
+// It prints the Go versions available for installation.
+package list
+
+import (
+	"os"
+
+	"github.com/nicholas-fedor/goUpdater/internal/download"
+	"github.com/nicholas-fedor/goUpdater/internal/logger"
+	"github.com/nicholas-fedor/goUpdater/internal/verify"
+	"github.com/spf13/cobra"
+)
+
+// NewListCmd creates the list command.
+func NewListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the Go versions available for installation",
+		Long: `List the published Go versions, newest first. The version installed in --install-dir is
+marked with an asterisk. Only stable releases are listed unless --unstable is given.`,
+		Aliases:                nil,
+		SuggestFor:             nil,
+		GroupID:                "",
+		Example:                "",
+		ValidArgs:              nil,
+		ValidArgsFunction:      nil,
+		Args:                   cobra.NoArgs,
+		ArgAliases:             nil,
+		BashCompletionFunction: "",
+		Deprecated:             "",
+		Annotations:            nil,
+		Version:                "",
+		PersistentPreRun:       nil,
+		PersistentPreRunE:      nil,
+		PreRun:                 nil,
+		PreRunE:                nil,
+		Run: func(cmd *cobra.Command, _ []string) {
+			installDir, _ := cmd.Flags().GetString("install-dir")
+			includeUnstable, _ := cmd.Flags().GetBool("unstable")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+
+			installedVersion, err := verify.GetInstalledVersion(installDir)
+			if err != nil {
+				logger.Debugf("No installed Go version to mark in %s: %v", installDir, err)
+
+				installedVersion = ""
+			}
+
+			err = download.PrintVersions(includeUnstable, installedVersion, jsonOutput)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+		RunE:               nil,
+		PostRun:            nil,
+		PostRunE:           nil,
+		PersistentPostRun:  nil,
+		PersistentPostRunE: nil,
+		FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: false},
+		CompletionOptions: cobra.CompletionOptions{
+			DisableDefaultCmd:         false,
+			DisableNoDescFlag:         false,
+			DisableDescriptions:       false,
+			HiddenDefaultCmd:          false,
+			DefaultShellCompDirective: nil,
+		},
+		TraverseChildren:           false,
+		Hidden:                     false,
+		SilenceErrors:              false,
+		SilenceUsage:               false,
+		DisableFlagParsing:         false,
+		DisableAutoGenTag:          false,
+		DisableFlagsInUseLine:      false,
+		DisableSuggestions:         false,
+		SuggestionsMinimumDistance: 0,
+	}
+	cmd.Flags().StringP("install-dir", "d", "/usr/local/go", "Directory whose installed Go version is marked")
+	cmd.Flags().Bool("unstable", false, "Include beta and release candidate versions")
+
+	return cmd
+}
@@ -0,0 +1,38 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package list_test provides tests for the list command.
+package list_test
+
+import (
+	"testing"
+
+	"github.com/nicholas-fedor/goUpdater/cmd/list"
+)
+
+func TestNewListCmd(t *testing.T) {
+	t.Parallel()
+
+	cmd := list.NewListCmd()
+
+	if cmd.Use != "list" {
+		t.Errorf("Expected command use to be 'list', got %s", cmd.Use)
+	}
+
+	if cmd.Run == nil {
+		t.Error("Expected command to have a Run function")
+	}
+
+	for name, want := range map[string]string{"install-dir": "/usr/local/go", "unstable": "false"} {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			t.Errorf("%s flag not found", name)
+
+			continue
+		}
+
+		if flag.DefValue != want {
+			t.Errorf("%s default = %q, want %q", name, flag.DefValue, want)
+		}
+	}
+}
@@ -8,8 +8,12 @@ import (
 
 	"github.com/nicholas-fedor/goUpdater/cmd/download"
 	"github.com/nicholas-fedor/goUpdater/cmd/install"
+	"github.com/nicholas-fedor/goUpdater/cmd/list"
+	"github.com/nicholas-fedor/goUpdater/cmd/rollback"
 	"github.com/nicholas-fedor/goUpdater/cmd/uninstall"
 	"github.com/nicholas-fedor/goUpdater/cmd/update"
+	"github.com/nicholas-fedor/goUpdater/cmd/url"
+	"github.com/nicholas-fedor/goUpdater/cmd/use"
 	"github.com/nicholas-fedor/goUpdater/cmd/verify"
 	"github.com/nicholas-fedor/goUpdater/cmd/version"
 )
@@ -19,8 +23,12 @@ import (
 func RegisterCommands(rootCmd *cobra.Command) {
 	rootCmd.AddCommand(download.NewDownloadCmd())
 	rootCmd.AddCommand(install.NewInstallCmd())
+	rootCmd.AddCommand(list.NewListCmd())
+	rootCmd.AddCommand(rollback.NewRollbackCmd())
 	rootCmd.AddCommand(uninstall.NewUninstallCmd())
 	rootCmd.AddCommand(update.NewUpdateCmd())
+	rootCmd.AddCommand(url.NewURLCmd())
+	rootCmd.AddCommand(use.NewUseCmd())
 	rootCmd.AddCommand(verify.NewVerifyCmd())
 	rootCmd.AddCommand(version.NewVersionCmd())
 }
@@ -0,0 +1,151 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package rollback provides the rollback command for goUpdater.
+// It restores the Go installation that the last update replaced.
+package rollback
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/nicholas-fedor/goUpdater/internal/cli"
+	"github.com/nicholas-fedor/goUpdater/internal/logger"
+	"github.com/nicholas-fedor/goUpdater/internal/privileges"
+	"github.com/nicholas-fedor/goUpdater/internal/update"
+	"github.com/nicholas-fedor/goUpdater/internal/verify"
+	"github.com/spf13/cobra"
+)
+
+// rollback asks for confirmation unless assumeYes is set, then restores the previous installation.
+// It runs after any elevation, so the prompt is shown only once. With jsonOutput, the prompt is
+// written to stderr and the result is printed as JSON.
+func rollback(installDir string, assumeYes, jsonOutput bool) error {
+	started := time.Now()
+
+	previousVersion, err := update.BackupVersion(installDir)
+	if err != nil {
+		return fmt.Errorf("cannot roll back %s: %w", installDir, err)
+	}
+
+	currentVersion, err := verify.GetInstalledVersion(installDir)
+	if err != nil {
+		currentVersion = ""
+	}
+
+	if !assumeYes {
+		var promptOut io.Writer = os.Stdout
+		if jsonOutput {
+			promptOut = os.Stderr
+		}
+
+		replaced := currentVersion
+		if replaced == "" {
+			replaced = "the current installation"
+		}
+
+		prompt := fmt.Sprintf("Replace %s in %s with %s?", replaced, installDir, previousVersion)
+
+		confirmed, err := cli.Confirm(os.Stdin, promptOut, prompt)
+		if err != nil {
+			return fmt.Errorf("failed to confirm rollback: %w", err)
+		}
+
+		if !confirmed {
+			logger.Info("Rollback cancelled.")
+
+			return nil
+		}
+	}
+
+	restoredVersion, err := update.Rollback(installDir)
+	if err != nil {
+		return fmt.Errorf("restoring %s in %s: %w", previousVersion, installDir, err)
+	}
+
+	logger.Infof("Rolled back %s to %s", installDir, restoredVersion)
+
+	if jsonOutput {
+		err = cli.PrintJSON(os.Stdout, &update.Report{
+			InstallDir:  installDir,
+			FromVersion: currentVersion,
+			ToVersion:   restoredVersion,
+			Action:      update.ActionRolledBack,
+			DurationMs:  time.Since(started).Milliseconds(),
+		})
+		if err != nil {
+			return fmt.Errorf("printing result: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// NewRollbackCmd creates the rollback command.
+func NewRollbackCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Restore the Go installation replaced by the last update",
+		Long: `Restore the Go installation that the last update replaced. An update run with --keep-backup keeps
+the previous installation next to the install directory (for example /usr/local/go.bak); rollback swaps it
+back into place and verifies it. The installation it replaces becomes the new backup, so running rollback again
+undoes the rollback.`,
+		Aliases:                nil,
+		SuggestFor:             nil,
+		GroupID:                "",
+		Example:                "",
+		ValidArgs:              nil,
+		ValidArgsFunction:      nil,
+		Args:                   nil,
+		ArgAliases:             nil,
+		BashCompletionFunction: "",
+		Deprecated:             "",
+		Annotations:            nil,
+		Version:                "",
+		PersistentPreRun:       nil,
+		PersistentPreRunE:      nil,
+		PreRun:                 nil,
+		PreRunE:                nil,
+		Run: func(cmd *cobra.Command, _ []string) {
+			installDir, _ := cmd.Flags().GetString("install-dir")
+			assumeYes, _ := cmd.Flags().GetBool("yes")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+
+			err := privileges.ElevateIfRequired(installDir, func() error {
+				return rollback(installDir, assumeYes, jsonOutput)
+			})
+			if err != nil {
+				logger.Errorf("Error rolling back Go: %v", err)
+				os.Exit(1)
+			}
+		},
+		RunE:               nil,
+		PostRun:            nil,
+		PostRunE:           nil,
+		PersistentPostRun:  nil,
+		PersistentPostRunE: nil,
+		FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: false},
+		CompletionOptions: cobra.CompletionOptions{
+			DisableDefaultCmd:         false,
+			DisableNoDescFlag:         false,
+			DisableDescriptions:       false,
+			HiddenDefaultCmd:          false,
+			DefaultShellCompDirective: nil,
+		},
+		TraverseChildren:           false,
+		Hidden:                     false,
+		SilenceErrors:              false,
+		SilenceUsage:               false,
+		DisableFlagParsing:         false,
+		DisableAutoGenTag:          false,
+		DisableFlagsInUseLine:      false,
+		DisableSuggestions:         false,
+		SuggestionsMinimumDistance: 0,
+	}
+	cmd.Flags().StringP("install-dir", "d", "/usr/local/go", "Directory whose previous Go installation to restore")
+	cmd.Flags().BoolP("yes", "y", false, "Restore without asking for confirmation")
+
+	return cmd
+}
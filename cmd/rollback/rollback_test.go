@@ -0,0 +1,53 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package rollback_test provides tests for the rollback command.
+package rollback_test
+
+import (
+	"testing"
+
+	"github.com/nicholas-fedor/goUpdater/cmd/rollback"
+)
+
+func TestNewRollbackCmd(t *testing.T) {
+	t.Parallel()
+
+	cmd := rollback.NewRollbackCmd()
+
+	if cmd.Use != "rollback" {
+		t.Errorf("Expected command use to be 'rollback', got %s", cmd.Use)
+	}
+
+	if cmd.Short == "" || cmd.Long == "" {
+		t.Error("Expected command to have short and long descriptions")
+	}
+
+	if cmd.Run == nil {
+		t.Error("Expected command to have a Run function")
+	}
+}
+
+func TestRollbackCmdFlags(t *testing.T) {
+	t.Parallel()
+
+	cmd := rollback.NewRollbackCmd()
+
+	installDirFlag := cmd.Flags().Lookup("install-dir")
+	if installDirFlag == nil {
+		t.Fatal("install-dir flag not found")
+	}
+
+	if installDirFlag.DefValue != "/usr/local/go" || installDirFlag.Shorthand != "d" {
+		t.Errorf("install-dir flag = %q (-%s), want /usr/local/go (-d)", installDirFlag.DefValue, installDirFlag.Shorthand)
+	}
+
+	yesFlag := cmd.Flags().Lookup("yes")
+	if yesFlag == nil {
+		t.Fatal("yes flag not found")
+	}
+
+	if yesFlag.DefValue != "false" || yesFlag.Shorthand != "y" {
+		t.Errorf("yes flag = %q (-%s), want false (-y)", yesFlag.DefValue, yesFlag.Shorthand)
+	}
+}
@@ -5,12 +5,41 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
 	"os"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"time"
 
+	"github.com/nicholas-fedor/goUpdater/internal/config"
+	"github.com/nicholas-fedor/goUpdater/internal/download"
 	"github.com/nicholas-fedor/goUpdater/internal/logger"
+	"github.com/nicholas-fedor/goUpdater/internal/privileges"
+	"github.com/nicholas-fedor/goUpdater/internal/verify"
+	"github.com/nicholas-fedor/goUpdater/internal/version"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
+// panicExitCode is the exit code used after recovering from an unexpected panic.
+// It matches EX_SOFTWARE from sysexits.h to distinguish crashes from ordinary failures.
+const panicExitCode = 70
+
+// errInvalidProxy indicates a --proxy value that is not an absolute URL.
+var errInvalidProxy = errors.New("proxy must be an absolute URL such as http://proxy.example.com:3128")
+
+// errVerboseQuiet indicates that --verbose and --quiet were both given.
+var errVerboseQuiet = errors.New("--verbose and --quiet cannot be used together")
+
+// bugReportURL is where users are asked to report unexpected errors.
+const bugReportURL = "https://github.com/nicholas-fedor/goUpdater/issues/new"
+
 // NewRootCmd creates the base command when called without any subcommands.
 func NewRootCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -32,7 +61,87 @@ It automates keeping Go installations up-to-date with the latest stable releases
 		Version:                "",
 		PersistentPreRun: func(cmd *cobra.Command, _ []string) {
 			verbose, _ := cmd.Flags().GetBool("verbose")
+			quiet, _ := cmd.Flags().GetBool("quiet")
+
+			if verbose && quiet {
+				logger.Errorf("Invalid flags: %v", errVerboseQuiet)
+				os.Exit(1)
+			}
+
 			logger.SetVerbose(verbose)
+
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			logger.SetMachineReadable(jsonOutput)
+			logger.SetQuiet(quiet)
+			download.SetProgress(!quiet && !jsonOutput)
+
+			// Captured before the config file can set GO_UPDATER_BASE_URL, so only the caller's
+			// environment is forwarded.
+			privileges.SetForwardedArgs(forwardedEnvArgs(cmd))
+
+			err := applyBaseURLFlag(cmd)
+			if err != nil {
+				logger.Errorf("Error applying --base-url: %v", err)
+				os.Exit(1)
+			}
+
+			err = applyConfig(cmd)
+			if err != nil {
+				logger.Errorf("Error loading config: %v", err)
+				os.Exit(1)
+			}
+
+			workers, _ := cmd.Flags().GetInt("workers")
+			verify.SetWorkers(workers)
+
+			sudoPath, _ := cmd.Flags().GetString("sudo-path")
+			privileges.SetSudoPath(sudoPath)
+
+			elevationTool, _ := cmd.Flags().GetString("elevation-tool")
+
+			err = privileges.SetElevationTool(elevationTool)
+			if err != nil {
+				logger.Errorf("Error parsing --elevation-tool: %v", err)
+				os.Exit(1)
+			}
+
+			noElevate, _ := cmd.Flags().GetBool("no-elevate")
+			privileges.SetNoElevate(noElevate)
+			privileges.SetAllowedArgs(allowedFlags(cmd))
+
+			retries, _ := cmd.Flags().GetInt("retries")
+			retryDelay, _ := cmd.Flags().GetDuration("retry-delay")
+			download.SetRetryPolicy(retries, retryDelay)
+
+			tempDir, _ := cmd.Flags().GetString("tmpdir")
+			download.SetTempDir(tempDir)
+
+			_, err = download.TempDir()
+			if err != nil {
+				logger.Errorf("Error checking the temp directory: %v", err)
+				os.Exit(1)
+			}
+
+			removed, err := download.RemoveStaleTempDirs(download.StaleTempDirAge)
+			if err != nil {
+				logger.Debugf("Skipping the stale temp directory sweep: %v", err)
+			} else if removed > 0 {
+				logger.Debugf("Removed %d temp directories left by earlier runs", removed)
+			}
+
+			auditLogPath, _ := cmd.Flags().GetString("audit-log")
+
+			err = privileges.SetAuditLog(auditLogPath)
+			if err != nil {
+				logger.Errorf("Error opening --audit-log: %v", err)
+				os.Exit(1)
+			}
+
+			err = configureHTTPClient(cmd)
+			if err != nil {
+				logger.Errorf("Error configuring HTTP client: %v", err)
+				os.Exit(1)
+			}
 		},
 		PersistentPreRunE:  nil,
 		PreRun:             nil,
@@ -62,15 +171,233 @@ It automates keeping Go installations up-to-date with the latest stable releases
 		SuggestionsMinimumDistance: 0,
 	}
 	cmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose logging")
+	cmd.PersistentFlags().BoolP("quiet", "q", false, "Only log errors")
+	cmd.PersistentFlags().String("config", "",
+		"Config file supplying flag defaults (default ~/"+config.FileName+")")
+	cmd.PersistentFlags().Int("workers", 0,
+		"Maximum number of installations verified concurrently (default one per CPU)")
+	cmd.PersistentFlags().Bool("json", false,
+		"Print the command's result as JSON on stdout; log lines go to stderr and only warnings and errors are shown")
+	cmd.PersistentFlags().String("sudo-path", "",
+		"Path to the sudo binary used for elevation (overrides GOUPDATER_SUDO_PATH)")
+	cmd.PersistentFlags().String("elevation-tool", "",
+		"Elevation tool to use: sudo, doas, or pkexec (overrides GOUPDATER_ELEVATION_TOOL; default: sudo, then doas)")
+	cmd.PersistentFlags().Bool("no-elevate", false,
+		"Fail instead of prompting when elevated privileges are required (same as GO_UPDATER_NO_ELEVATE=1)")
+	cmd.PersistentFlags().String("audit-log", "",
+		"Append a JSON line for every elevation request and privileged operation to this file")
+	cmd.PersistentFlags().String("proxy", "",
+		"HTTP(S) proxy URL for downloads (overrides HTTP_PROXY and HTTPS_PROXY)")
+	cmd.PersistentFlags().Duration("http-timeout", 0,
+		"Timeout for connecting and receiving response headers (default 30s)")
+	cmd.PersistentFlags().Int("retries", 3, "Number of times to retry a download after a network or server error")
+	cmd.PersistentFlags().Duration("retry-delay", time.Second,
+		"Delay before the first download retry, doubled for each further retry")
+	cmd.PersistentFlags().String("tmpdir", "",
+		"Directory for downloads and staging, e.g. on a volume larger than /tmp (overrides "+download.TempDirEnv+")")
+	cmd.PersistentFlags().String("base-url", "",
+		"Mirror of https://go.dev/dl/ to fetch releases from (overrides "+download.BaseURLEnv+" and the config file)")
 
 	return cmd
 }
 
+// applyConfig loads the config file named by --config, or the default config file when it exists,
+// and uses its values as defaults for the install-dir, channel, and workers flags of cmd.
+// Flags given on the command line keep their values, and GO_UPDATER_BASE_URL takes precedence over base-url.
+// Flags set from the file are not marked as changed, so they do not conflict with mutually exclusive flags.
+func applyConfig(cmd *cobra.Command) error {
+	path, _ := cmd.Flags().GetString("config")
+	explicit := path != ""
+
+	if !explicit {
+		var err error
+
+		path, err = config.DefaultPath()
+		if err != nil {
+			logger.Debugf("Skipping the config file: %v", err)
+
+			return nil
+		}
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("loading %s: %w", path, err)
+	}
+
+	logger.Debugf("Loaded config file: %s", path)
+
+	defaults := map[string]string{
+		"install-dir": cfg.InstallDir,
+		"channel":     cfg.Channel,
+		"workers":     "",
+	}
+
+	if cfg.Workers > 0 {
+		defaults["workers"] = strconv.Itoa(cfg.Workers)
+	}
+
+	for name, value := range defaults {
+		flag := cmd.Flags().Lookup(name)
+		if value == "" || flag == nil || flag.Changed {
+			continue
+		}
+
+		err = flag.Value.Set(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q in %s: %w", name, value, path, err)
+		}
+	}
+
+	if cfg.BaseURL != "" && os.Getenv(download.BaseURLEnv) == "" {
+		err = os.Setenv(download.BaseURLEnv, cfg.BaseURL)
+		if err != nil {
+			return fmt.Errorf("applying base-url from %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// forwardedEnvArgs returns the --tmpdir and --base-url arguments that carry GO_UPDATER_TMPDIR and
+// GO_UPDATER_BASE_URL to an elevated re-execution, since sudo and doas drop them from the environment.
+// A variable is not forwarded when its flag was given, as the flag is already on the command line.
+func forwardedEnvArgs(cmd *cobra.Command) []string {
+	var args []string
+
+	for flag, env := range map[string]string{"tmpdir": download.TempDirEnv, "base-url": download.BaseURLEnv} {
+		value := os.Getenv(env)
+		if value == "" || cmd.Flags().Changed(flag) {
+			continue
+		}
+
+		args = append(args, "--"+flag+"="+value)
+	}
+
+	slices.Sort(args)
+
+	return args
+}
+
+// applyBaseURLFlag sets GO_UPDATER_BASE_URL from the --base-url flag, if given, so that it takes
+// precedence over both the environment and the config file.
+func applyBaseURLFlag(cmd *cobra.Command) error {
+	baseURL, _ := cmd.Flags().GetString("base-url")
+	if baseURL == "" {
+		return nil
+	}
+
+	err := os.Setenv(download.BaseURLEnv, baseURL)
+	if err != nil {
+		return fmt.Errorf("setting %s: %w", download.BaseURLEnv, err)
+	}
+
+	return nil
+}
+
+// forwardableFlags lists the flags, in both the --name and -shorthand forms, that may be passed on to the
+// elevated re-execution of goUpdater. The audit log (--audit-log) and the config file (--config) are left
+// out, so that they cannot steer the process running as root, and an elevation is refused when either is
+// given. The flags choosing how to elevate are dropped from the re-execution by the privileges package.
+//
+//nolint:gochecknoglobals
+var forwardableFlags = []string{
+	"--verbose", "-v", "--quiet", "-q", "--json", "--workers",
+	"--proxy", "--http-timeout", "--retries", "--retry-delay", "--tmpdir", "--base-url",
+	"--install-dir", "-d", "--install-dirs", "--auto-install", "-a", "--yes", "-y",
+	"--dest-owner", "--version", "--channel", "--check", "--signature-key", "--post-install-cmd",
+	"--dry-run", "--force", "--keep-backup", "--timeout",
+	"--slim", "--base-dir", "--checksum", "--checksum-file", "--checksum-algorithm",
+}
+
+// allowedFlags returns the flags of forwardableFlags that cmd accepts, including those inherited from its
+// parents. Only these may reach an elevated re-execution.
+func allowedFlags(cmd *cobra.Command) []string {
+	var names []string
+
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		for _, name := range []string{"--" + flag.Name, "-" + flag.Shorthand} {
+			if slices.Contains(forwardableFlags, name) {
+				names = append(names, name)
+			}
+		}
+	})
+
+	return names
+}
+
+// configureHTTPClient applies the --proxy and --http-timeout flags to the download client.
+// Without either flag, the default client is kept.
+func configureHTTPClient(cmd *cobra.Command) error {
+	proxy, _ := cmd.Flags().GetString("proxy")
+	timeout, _ := cmd.Flags().GetDuration("http-timeout")
+
+	if proxy == "" && timeout == 0 {
+		return nil
+	}
+
+	var proxyURL *url.URL
+
+	if proxy != "" {
+		var err error
+
+		proxyURL, err = url.Parse(proxy)
+		if err != nil {
+			return fmt.Errorf("invalid --proxy: %w", err)
+		}
+
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return fmt.Errorf("invalid --proxy %q: %w", proxy, errInvalidProxy)
+		}
+	}
+
+	download.SetHTTPClient(download.NewHTTPClient(download.WithProxy(proxyURL), download.WithTimeout(timeout)))
+
+	return nil
+}
+
 // Execute runs the root command.
 // This is called by main.main().
+// An unexpected panic is recovered and reported with a bug report prompt instead of a raw stack trace.
 func Execute(rootCmd *cobra.Command) {
+	defer handlePanic()
+
 	err := rootCmd.Execute()
 	if err != nil {
 		os.Exit(1)
 	}
 }
+
+// handlePanic recovers from a panic, reports it, and exits with panicExitCode.
+// It must be deferred directly so that recover can intercept the panic.
+func handlePanic() {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	logger.Errorf("Unexpected error: %v", recovered)
+	reportPanic(os.Stderr, recovered, debug.Stack())
+	os.Exit(panicExitCode)
+}
+
+// reportPanic writes a concise bug report for a recovered panic to the writer.
+// The report includes the panic value, goUpdater build information, and the stack trace.
+func reportPanic(writer io.Writer, recovered any, stack []byte) {
+	info := version.GetVersionInfo()
+
+	_, _ = fmt.Fprintf(writer, `goUpdater encountered an unexpected error; please file a bug at %s with this info:
+
+Panic: %v
+Version: %s
+Commit: %s
+Built: %s
+Runtime: %s %s/%s
+
+%s`, bugReportURL, recovered, info.Version, info.Commit, info.Date,
+		runtime.Version(), runtime.GOOS, runtime.GOARCH, stack)
+}
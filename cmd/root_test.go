@@ -0,0 +1,252 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nicholas-fedor/goUpdater/internal/download"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func TestReportPanic(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	reportPanic(&buf, "boom", []byte("goroutine 1 [running]:"))
+
+	output := buf.String()
+
+	for _, want := range []string{"please file a bug", bugReportURL, "Panic: boom", "Version: ", "goroutine 1 [running]:"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("reportPanic() output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestConfigureHTTPClientInvalidProxy(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewRootCmd()
+
+	err := cmd.ParseFlags([]string{"--proxy", "proxy.example.com:3128"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = configureHTTPClient(cmd)
+	if !errors.Is(err, errInvalidProxy) {
+		t.Errorf("configureHTTPClient() error = %v, want %v", err, errInvalidProxy)
+	}
+}
+
+func TestApplyConfig(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	err := os.WriteFile(path, []byte("install-dir: /opt/go\nchannel: rc\nworkers: 2\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootCmd := NewRootCmd()
+	child := &cobra.Command{Use: "child"} //nolint:exhaustruct
+	child.Flags().String("install-dir", "/usr/local/go", "")
+	child.Flags().String("channel", "stable", "")
+	child.Flags().StringSlice("install-dirs", nil, "")
+	child.MarkFlagsMutuallyExclusive("install-dir", "install-dirs")
+	rootCmd.AddCommand(child)
+
+	err = child.ParseFlags([]string{"--config", path, "--channel", "beta", "--install-dirs", "/a,/b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = applyConfig(child)
+	if err != nil {
+		t.Fatalf("applyConfig() error = %v", err)
+	}
+
+	installDir, _ := child.Flags().GetString("install-dir")
+	channel, _ := child.Flags().GetString("channel")
+	workers, _ := child.Flags().GetInt("workers")
+
+	if installDir != "/opt/go" || channel != "beta" || workers != 2 {
+		t.Errorf("after applyConfig(): install-dir=%s channel=%s workers=%d, want /opt/go, beta, 2",
+			installDir, channel, workers)
+	}
+
+	err = child.ValidateFlagGroups()
+	if err != nil {
+		t.Errorf("install-dir from the config file conflicts with --install-dirs: %v", err)
+	}
+}
+
+func TestApplyConfigMissingExplicitFile(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewRootCmd()
+
+	err := cmd.ParseFlags([]string{"--config", filepath.Join(t.TempDir(), "missing.yaml")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = applyConfig(cmd)
+	if err == nil {
+		t.Error("applyConfig() error = nil, want an error for a missing --config file")
+	}
+}
+
+func TestAllowedFlags(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd()
+	RegisterCommands(rootCmd)
+
+	updateCmd, _, err := rootCmd.Find([]string{"update"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Parsing merges the persistent root flags into the subcommand's flag set, as Execute does.
+	err = updateCmd.ParseFlags(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := allowedFlags(updateCmd)
+
+	for _, want := range []string{"--install-dir", "-d", "--verbose", "-v", "--tmpdir"} {
+		if !slices.Contains(names, want) {
+			t.Errorf("allowedFlags() = %v, missing %s", names, want)
+		}
+	}
+
+	for _, unwanted := range []string{"--checksum-file", "--audit-log", "--config", "--sudo-path", "--elevation-tool"} {
+		if slices.Contains(names, unwanted) {
+			t.Errorf("allowedFlags() = %v, includes %s", names, unwanted)
+		}
+	}
+}
+
+func TestForwardableFlagsAreRegistered(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd()
+	RegisterCommands(rootCmd)
+
+	registered := map[string]bool{}
+	visit := func(flag *pflag.Flag) {
+		registered["--"+flag.Name] = true
+		registered["-"+flag.Shorthand] = true
+	}
+
+	rootCmd.PersistentFlags().VisitAll(visit)
+
+	for _, cmd := range rootCmd.Commands() {
+		cmd.Flags().VisitAll(visit)
+	}
+
+	for _, name := range forwardableFlags {
+		if !registered[name] {
+			t.Errorf("forwardable flag %s is not registered by any command", name)
+		}
+	}
+}
+
+func TestJSONFlagIsGlobal(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd()
+	RegisterCommands(rootCmd)
+
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.LocalNonPersistentFlags().Lookup("json") != nil {
+			t.Errorf("%s defines its own --json flag, shadowing the persistent root flag", cmd.Name())
+		}
+	}
+}
+
+func TestForwardedEnvArgs(t *testing.T) {
+	t.Setenv(download.TempDirEnv, "/var/tmp")
+	t.Setenv(download.BaseURLEnv, "https://mirror.example.com/golang/")
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "variables only",
+			args: nil,
+			want: []string{"--base-url=https://mirror.example.com/golang/", "--tmpdir=/var/tmp"},
+		},
+		{
+			name: "flag given",
+			args: []string{"--tmpdir", "/srv/tmp"},
+			want: []string{"--base-url=https://mirror.example.com/golang/"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			cmd := NewRootCmd()
+
+			err := cmd.ParseFlags(testCase.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := forwardedEnvArgs(cmd)
+			if !slices.Equal(got, testCase.want) {
+				t.Errorf("forwardedEnvArgs() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+// TestCompletionCommand is not parallel because executing the root command runs PersistentPreRun,
+// which configures global logger, privilege, and HTTP client state.
+func TestCompletionCommand(t *testing.T) {
+	tests := []struct {
+		shell string
+		want  string
+	}{
+		{shell: "bash", want: "bash completion"},
+		{shell: "zsh", want: "#compdef goUpdater"},
+		{shell: "fish", want: "fish completion"},
+		{shell: "powershell", want: "Register-ArgumentCompleter"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.shell, func(t *testing.T) {
+			rootCmd := NewRootCmd()
+			RegisterCommands(rootCmd)
+
+			var buf bytes.Buffer
+
+			rootCmd.SetOut(&buf)
+			rootCmd.SetArgs([]string{"completion", testCase.shell})
+
+			err := rootCmd.Execute()
+			if err != nil {
+				t.Fatalf("completion %s: %v", testCase.shell, err)
+			}
+
+			if !strings.Contains(buf.String(), testCase.want) {
+				t.Errorf("completion %s output does not contain %q", testCase.shell, testCase.want)
+			}
+		})
+	}
+}
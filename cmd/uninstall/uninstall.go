@@ -6,13 +6,65 @@
 package uninstall
 
 import (
+	"fmt"
+	"io"
 	"os"
 
+	"github.com/nicholas-fedor/goUpdater/internal/cli"
+	"github.com/nicholas-fedor/goUpdater/internal/logger"
 	"github.com/nicholas-fedor/goUpdater/internal/privileges"
 	"github.com/nicholas-fedor/goUpdater/internal/uninstall"
 	"github.com/spf13/cobra"
 )
 
+// result is the --json output of the uninstall command.
+type result struct {
+	InstallDir string `json:"installDir"`
+	Removed    bool   `json:"removed"`
+}
+
+// removeInstallation checks that installDir holds a Go distribution, asks for confirmation unless
+// assumeYes is set, and removes it. It runs after any elevation, so the prompt is shown only once.
+// With jsonOutput, the prompt is written to stderr and the result is printed as JSON.
+func removeInstallation(installDir string, assumeYes, jsonOutput bool) error {
+	version, err := uninstall.ValidateInstallation(installDir)
+	if err != nil {
+		return fmt.Errorf("refusing to uninstall: %w", err)
+	}
+
+	removed := true
+
+	if !assumeYes {
+		var promptOut io.Writer = os.Stdout
+		if jsonOutput {
+			promptOut = os.Stderr
+		}
+
+		removed, err = cli.Confirm(os.Stdin, promptOut, fmt.Sprintf("Remove Go %s from %s?", version, installDir))
+		if err != nil {
+			return fmt.Errorf("failed to confirm uninstall: %w", err)
+		}
+	}
+
+	if removed {
+		err = uninstall.Remove(installDir)
+		if err != nil {
+			return fmt.Errorf("removing %s: %w", installDir, err)
+		}
+	} else {
+		logger.Info("Uninstall cancelled.")
+	}
+
+	if jsonOutput {
+		err = cli.PrintJSON(os.Stdout, result{InstallDir: installDir, Removed: removed})
+		if err != nil {
+			return fmt.Errorf("printing result: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // createUninstallCommand creates the cobra command with basic configuration.
 // It sets up the command structure and flags for the uninstall command.
 func createUninstallCommand() *cobra.Command {
@@ -20,7 +72,8 @@ func createUninstallCommand() *cobra.Command {
 		Use:   "uninstall",
 		Short: "Uninstall Go from the system",
 		Long: `Uninstall Go by removing the installation directory.
-By default, this removes Go from /usr/local/go.`,
+By default, this removes Go from /usr/local/go. The directory must contain the VERSION file
+of a Go distribution, and the removal is confirmed interactively unless --yes is given.`,
 		Aliases:                nil,
 		SuggestFor:             nil,
 		GroupID:                "",
@@ -63,6 +116,7 @@ By default, this removes Go from /usr/local/go.`,
 	}
 
 	cmd.Flags().StringP("install-dir", "d", "/usr/local/go", "Directory from which to uninstall Go")
+	cmd.Flags().BoolP("yes", "y", false, "Remove the installation without asking for confirmation")
 
 	return cmd
 }
@@ -73,9 +127,11 @@ func NewUninstallCmd() *cobra.Command {
 
 	cmd.Run = func(cmd *cobra.Command, _ []string) {
 		installDir, _ := cmd.Flags().GetString("install-dir")
+		assumeYes, _ := cmd.Flags().GetBool("yes")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
 
-		err := privileges.ElevateAndExecute(func() error {
-			return uninstall.Remove(installDir)
+		err := privileges.ElevateIfRequired(installDir, func() error {
+			return removeInstallation(installDir, assumeYes, jsonOutput)
 		})
 		if err != nil {
 			cmd.PrintErrln(err)
@@ -82,6 +82,17 @@ func TestUninstallCmdFlags(t *testing.T) {
 	if value != "/custom/path" {
 		t.Errorf("Expected install-dir flag value to be '/custom/path', got %s", value)
 	}
+
+	// Test yes flag
+	yesFlag := cmd.Flags().Lookup("yes")
+	if yesFlag == nil {
+		t.Fatal("yes flag not found")
+	}
+
+	if yesFlag.Shorthand != "y" || yesFlag.DefValue != "false" {
+		t.Errorf("Expected yes flag with shorthand 'y' and default false, got '%s' and %s",
+			yesFlag.Shorthand, yesFlag.DefValue)
+	}
 }
 
 func TestUninstallCmdStructure(t *testing.T) {
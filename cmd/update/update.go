@@ -6,20 +6,177 @@
 package update
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"time"
 
+	"github.com/nicholas-fedor/goUpdater/internal/cli"
+	"github.com/nicholas-fedor/goUpdater/internal/download"
+	"github.com/nicholas-fedor/goUpdater/internal/install"
 	"github.com/nicholas-fedor/goUpdater/internal/logger"
 	"github.com/nicholas-fedor/goUpdater/internal/update"
 	"github.com/spf13/cobra"
 )
 
+// updateAvailableExitCode is the exit code of --check when a newer Go release is available.
+const updateAvailableExitCode = 2
+
+// runCheck reports whether an update is available for installDir and returns the exit code for --check:
+// 0 when Go is up to date, updateAvailableExitCode when an update is available, and 1 on error.
+// With jsonOutput, the availability is printed as JSON instead of logged.
+func runCheck(installDir string, jsonOutput bool) int {
+	availability, err := update.CheckForUpdate(installDir)
+	if err != nil {
+		logger.Errorf("Error checking for updates: %v", err)
+
+		return 1
+	}
+
+	if jsonOutput {
+		err = cli.PrintJSON(os.Stdout, availability)
+		if err != nil {
+			logger.Errorf("Error printing result: %v", err)
+
+			return 1
+		}
+	}
+
+	if !availability.UpdateAvailable {
+		logger.Infof("Go is up to date (%s)", availability.Installed)
+
+		return 0
+	}
+
+	if availability.Installed == "" {
+		logger.Infof("Go is not installed in %s; %s is available", installDir, availability.Latest)
+	} else {
+		logger.Infof("Go %s is available (installed: %s)", availability.Latest, availability.Installed)
+	}
+
+	return updateAvailableExitCode
+}
+
+// bytesPerMB converts download sizes to megabytes for display.
+const bytesPerMB = 1 << 20
+
+// runDryRun reports what an update of installDir would do without changing anything or elevating.
+// With jsonOutput, the plan is printed as JSON instead of logged.
+func runDryRun(installDir, targetVersion string, autoInstall, jsonOutput bool) error {
+	plan, err := update.PlanUpdate(installDir, targetVersion, autoInstall)
+	if err != nil {
+		return fmt.Errorf("planning the update: %w", err)
+	}
+
+	if jsonOutput {
+		return cli.PrintJSON(os.Stdout, plan)
+	}
+
+	switch plan.Action {
+	case update.ActionSkipped:
+		logger.Infof("Dry run: Go in %s is already up to date (%s); nothing would change", plan.InstallDir, plan.FromVersion)
+
+		return nil
+	case update.ActionInstalled:
+		logger.Infof("Dry run: would install Go %s in %s", plan.ToVersion, plan.InstallDir)
+	case update.ActionUpdated:
+		logger.Infof("Dry run: would update Go in %s from %s to %s", plan.InstallDir, plan.FromVersion, plan.ToVersion)
+	case update.ActionReinstalled:
+		logger.Infof("Dry run: would reinstall Go %s in %s", plan.ToVersion, plan.InstallDir)
+	}
+
+	logger.Infof("Dry run: would download %s (%.1f MB)", plan.ArchiveURL, float64(plan.DownloadSize)/bytesPerMB)
+
+	return nil
+}
+
+// updateContext returns the context bounding an update: it is cancelled on SIGINT or SIGTERM, so that
+// the update stops and restores the previous installation, and after timeout unless timeout is zero.
+func updateContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, stop := cli.InterruptContext(context.Background())
+	if timeout <= 0 {
+		return ctx, stop
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// updateAll updates every directory in installDirs, applying --dest-owner to each one that changed.
+// With jsonOutput, the reports are printed as a JSON array once every directory has succeeded; on a
+// failure nothing is printed, leaving the caller to print the error as JSON.
+func updateAll(
+	ctx context.Context,
+	installDirs []string,
+	autoInstall bool,
+	destOwner string,
+	uid, gid int,
+	jsonOutput bool,
+) error {
+	reports, err := update.UpdateAllWithPrivilegesContext(ctx, installDirs, autoInstall)
+
+	for _, report := range reports {
+		logReport(&report)
+
+		if report.Action == update.ActionSkipped {
+			continue
+		}
+
+		install.LogPathGuidance(report.InstallDir, report.Action == update.ActionInstalled)
+
+		if destOwner == "" {
+			continue
+		}
+
+		chownErr := install.ChownTree(report.InstallDir, uid, gid)
+		if chownErr != nil {
+			err = errors.Join(err, fmt.Errorf("applying --dest-owner to %s: %w", report.InstallDir, chownErr))
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		return cli.PrintJSON(os.Stdout, reports)
+	}
+
+	return nil
+}
+
+// logReport logs the outcome of an update.
+func logReport(report *update.Report) {
+	switch report.Action {
+	case update.ActionSkipped:
+		logger.Infof("Go is already up to date (%s)", report.FromVersion)
+	case update.ActionInstalled:
+		logger.Infof("Installed Go %s in %dms", report.ToVersion, report.DurationMs)
+	case update.ActionUpdated:
+		logger.Infof("Updated Go from %s to %s in %dms", report.FromVersion, report.ToVersion, report.DurationMs)
+	case update.ActionReinstalled:
+		logger.Infof("Reinstalled Go %s in %dms", report.ToVersion, report.DurationMs)
+	}
+}
+
 // NewUpdateCmd creates the update command.
 func NewUpdateCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "update",
 		Short: "Update Go to the latest version",
 		Long: `Update Go by downloading the latest version, uninstalling the current installation,
-installing the new version, and verifying the installation. By default, Go is updated in /usr/local/go.`,
+installing the new version, and verifying the installation. By default, Go is updated in /usr/local/go.
+With --version, a specific published Go version is targeted instead of the latest.
+With --channel rc or --channel beta, the newest release candidate or beta is accepted when it is newer
+than the latest stable release.
+With --force, the target version is installed again even when it is already installed.
+With --keep-backup, the replaced installation is kept as <install-dir>.bak for the rollback command.
+With --dry-run, the planned update is printed without downloading, changing anything, or elevating.`,
 		Aliases:                nil,
 		SuggestFor:             nil,
 		GroupID:                "",
@@ -39,13 +196,124 @@ installing the new version, and verifying the installation. By default, Go is up
 		Run: func(cmd *cobra.Command, _ []string) {
 			updateDir, _ := cmd.Flags().GetString("install-dir")
 			autoInstall, _ := cmd.Flags().GetBool("auto-install")
+			destOwner, _ := cmd.Flags().GetString("dest-owner")
+			signatureKey, _ := cmd.Flags().GetString("signature-key")
+			postInstallCmd, _ := cmd.Flags().GetString("post-install-cmd")
+			targetVersion, _ := cmd.Flags().GetString("version")
+			channelName, _ := cmd.Flags().GetString("channel")
+			checkOnly, _ := cmd.Flags().GetBool("check")
+			installDirs, _ := cmd.Flags().GetStringSlice("install-dirs")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			force, _ := cmd.Flags().GetBool("force")
+			keepBackup, _ := cmd.Flags().GetBool("keep-backup")
 			logger.Debugf("Starting update operation: installDir=%s, autoInstall=%t", updateDir, autoInstall)
 
-			err := update.GoWithPrivileges(updateDir, autoInstall)
+			if checkOnly {
+				os.Exit(runCheck(updateDir, jsonOutput))
+			}
+
+			var uid, gid int
+
+			if destOwner != "" {
+				var err error
+
+				uid, gid, err = install.ParseOwner(destOwner)
+				if err != nil {
+					logger.Errorf("Error resolving --dest-owner: %v", err)
+					os.Exit(1)
+				}
+			}
+
+			channel, err := download.ParseChannel(channelName)
+			if err != nil {
+				logger.Errorf("Error parsing --channel: %v", err)
+				os.Exit(1)
+			}
+
+			if channel != download.ChannelStable {
+				release, err := download.GetLatestForChannel(channel)
+				if err != nil {
+					logger.Errorf("Error resolving the latest %s release: %v", channel, err)
+					os.Exit(1)
+				}
+
+				targetVersion = release.Version
+			}
+
+			update.SetForce(force)
+			update.SetKeepBackup(keepBackup)
+
+			if dryRun {
+				err = runDryRun(updateDir, targetVersion, autoInstall, jsonOutput)
+				if err != nil {
+					logger.Errorf("Error: %v", err)
+					os.Exit(1)
+				}
+
+				return
+			}
+
+			update.SetSignatureKey(signatureKey)
+			update.SetPostInstallCommand(postInstallCmd)
+
+			// Cancelled explicitly rather than deferred, as the error paths below exit the process
+			ctx, cancel := updateContext(timeout)
+
+			if len(installDirs) > 0 {
+				err = updateAll(ctx, installDirs, autoInstall, destOwner, uid, gid, jsonOutput)
+
+				cancel()
+
+				if err != nil {
+					logger.Errorf("Error updating Go: %v", err)
+
+					if jsonOutput {
+						_ = cli.PrintJSONError(os.Stdout, err)
+					}
+
+					os.Exit(1)
+				}
+
+				return
+			}
+
+			report, err := update.GoVersionReportWithPrivilegesContext(ctx, updateDir, targetVersion, autoInstall)
+
+			cancel()
+
 			if err != nil {
 				logger.Errorf("Error updating Go: %v", err)
+
+				if jsonOutput {
+					_ = cli.PrintJSONError(os.Stdout, err)
+				}
+
 				os.Exit(1)
 			}
+
+			logReport(report)
+
+			if report.Action != update.ActionSkipped {
+				install.LogPathGuidance(updateDir, report.Action == update.ActionInstalled)
+			}
+
+			if report.Action != update.ActionSkipped && destOwner != "" {
+				err = install.ChownTree(updateDir, uid, gid)
+				if err != nil {
+					logger.Errorf("Error applying --dest-owner: %v", err)
+					os.Exit(1)
+				}
+			}
+
+			if jsonOutput {
+				err = cli.PrintJSON(os.Stdout, report)
+				if err != nil {
+					logger.Errorf("Error printing result: %v", err)
+					os.Exit(1)
+				}
+			}
 		},
 		RunE:               nil,
 		PostRun:            nil,
@@ -72,6 +340,36 @@ installing the new version, and verifying the installation. By default, Go is up
 	}
 	cmd.Flags().StringP("install-dir", "d", "/usr/local/go", "Directory where Go should be updated")
 	cmd.Flags().BoolP("auto-install", "a", false, "Automatically install Go if not present")
+	cmd.Flags().String("dest-owner", "", "Change ownership of the updated tree to user[:group] after the update")
+	cmd.Flags().String("version", "", "Update to this published Go version (e.g. go1.21.13) instead of the latest")
+	cmd.Flags().String("channel", string(download.ChannelStable),
+		"Release channel to update from: stable, rc, or beta")
+	cmd.MarkFlagsMutuallyExclusive("version", "channel")
+	cmd.Flags().Bool("check", false,
+		"Only report whether a newer stable release is available; exits 2 if so, without changing anything")
+	cmd.MarkFlagsMutuallyExclusive("check", "version")
+	cmd.MarkFlagsMutuallyExclusive("check", "channel")
+	cmd.Flags().StringSlice("install-dirs", nil,
+		"Update the latest stable Go in each of these comma-separated directories instead of --install-dir")
+	cmd.MarkFlagsMutuallyExclusive("install-dirs", "install-dir")
+	cmd.MarkFlagsMutuallyExclusive("install-dirs", "version")
+	cmd.MarkFlagsMutuallyExclusive("install-dirs", "channel")
+	cmd.MarkFlagsMutuallyExclusive("install-dirs", "check")
+	cmd.Flags().String("signature-key", "",
+		"Verify the archive's detached signature against this OpenPGP public key before updating")
+	cmd.Flags().String("post-install-cmd", "",
+		"Shell command to run after Go has been updated and verified, e.g. 'go env -w GOPROXY=...'")
+	cmd.Flags().Bool("dry-run", false,
+		"Print the planned update (versions, install directory, download size) without changing anything")
+	cmd.MarkFlagsMutuallyExclusive("dry-run", "check")
+	cmd.MarkFlagsMutuallyExclusive("dry-run", "install-dirs")
+	cmd.Flags().Bool("force", false,
+		"Reinstall even when the installed Go is already up to date, e.g. to repair a damaged installation")
+	cmd.MarkFlagsMutuallyExclusive("force", "check")
+	cmd.Flags().Bool("keep-backup", false,
+		"Keep the replaced installation as <install-dir>.bak after a verified update, for the rollback command")
+	cmd.Flags().Duration("timeout", 0,
+		"Give up on the update after this long (e.g. 5m), restoring the previous installation; 0 means no limit")
 
 	return cmd
 }
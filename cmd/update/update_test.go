@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/nicholas-fedor/goUpdater/cmd/update"
+	"github.com/spf13/cobra"
 )
 
 func TestNewUpdateCmd(t *testing.T) {
@@ -44,6 +45,10 @@ func TestUpdateCmdFlags(t *testing.T) {
 
 	testInstallDirFlag(t)
 	testAutoInstallFlag(t)
+	testChannelFlag(t)
+	testDryRunFlag(t)
+	testTimeoutFlag(t)
+	testForceFlag(t)
 }
 
 func testInstallDirFlag(t *testing.T) {
@@ -128,6 +133,115 @@ func testAutoInstallFlag(t *testing.T) {
 	})
 }
 
+func testChannelFlag(t *testing.T) {
+	t.Helper()
+	t.Run("channel flag", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := update.NewUpdateCmd()
+
+		// Test that the flag exists
+		flag := cmd.Flags().Lookup("channel")
+		if flag == nil {
+			t.Fatalf("Expected command to have channel flag")
+		}
+
+		// Test default value
+		if flag.DefValue != "stable" {
+			t.Errorf("Expected default value to be 'stable', got '%s'", flag.DefValue)
+		}
+
+		// Test that --channel and --version cannot be combined
+		cmd.SetArgs([]string{"--channel", "rc", "--version", "go1.21.13"})
+		cmd.Run = func(*cobra.Command, []string) {}
+
+		err := cmd.Execute()
+		if err == nil {
+			t.Error("Expected an error when combining --channel and --version")
+		}
+	})
+}
+
+func testDryRunFlag(t *testing.T) {
+	t.Helper()
+	t.Run("dry-run flag", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := update.NewUpdateCmd()
+
+		// Test that the flag exists
+		flag := cmd.Flags().Lookup("dry-run")
+		if flag == nil {
+			t.Fatalf("Expected command to have dry-run flag")
+		}
+
+		// Test default value
+		if flag.DefValue != "false" {
+			t.Errorf("Expected default value to be 'false', got '%s'", flag.DefValue)
+		}
+
+		// Test that --dry-run and --check cannot be combined
+		cmd.SetArgs([]string{"--dry-run", "--check"})
+		cmd.Run = func(*cobra.Command, []string) {}
+
+		err := cmd.Execute()
+		if err == nil {
+			t.Error("Expected an error when combining --dry-run and --check")
+		}
+	})
+}
+
+func testTimeoutFlag(t *testing.T) {
+	t.Helper()
+	t.Run("timeout flag", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := update.NewUpdateCmd()
+
+		flag := cmd.Flags().Lookup("timeout")
+		if flag == nil {
+			t.Fatalf("Expected command to have timeout flag")
+		}
+
+		// A zero default means no limit
+		if flag.DefValue != "0s" {
+			t.Errorf("Expected default value to be '0s', got '%s'", flag.DefValue)
+		}
+
+		err := cmd.Flags().Set("timeout", "5m")
+		if err != nil {
+			t.Errorf("Expected --timeout to accept a duration, got %v", err)
+		}
+	})
+}
+
+func testForceFlag(t *testing.T) {
+	t.Helper()
+	t.Run("force flag", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := update.NewUpdateCmd()
+
+		flag := cmd.Flags().Lookup("force")
+		if flag == nil {
+			t.Fatalf("Expected command to have force flag")
+		}
+
+		if flag.DefValue != "false" {
+			t.Errorf("Expected default value to be 'false', got '%s'", flag.DefValue)
+		}
+
+		// Test that --force and --check cannot be combined
+		cmd.SetArgs([]string{"--force", "--check"})
+		cmd.Run = func(*cobra.Command, []string) {}
+
+		err := cmd.Execute()
+		if err == nil {
+			t.Error("Expected an error when combining --force and --check")
+		}
+	})
+}
+
 func TestUpdateCmdFlagCombinations(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,79 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package url provides the url command for goUpdater.
+// It prints the download URL and checksum of a Go archive without downloading it.
+package url
+
+import (
+	"os"
+
+	"github.com/nicholas-fedor/goUpdater/internal/download"
+	"github.com/spf13/cobra"
+)
+
+// NewURLCmd creates the url command.
+func NewURLCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "url",
+		Short: "Print the download URL and SHA-256 checksum of a Go archive",
+		Long: `Resolve the download URL and SHA-256 checksum of a Go archive from the official release feed
+without downloading it. By default, resolves the latest stable release for the current platform.
+A language version such as go1.22 resolves to its newest patch release.
+Plain output prints the URL and checksum on separate lines for use in scripts and Dockerfiles.`,
+		Aliases:                nil,
+		SuggestFor:             nil,
+		GroupID:                "",
+		Example:                "  goUpdater url --version go1.22 --goos linux --goarch arm64",
+		ValidArgs:              nil,
+		ValidArgsFunction:      nil,
+		Args:                   cobra.NoArgs,
+		ArgAliases:             nil,
+		BashCompletionFunction: "",
+		Deprecated:             "",
+		Annotations:            nil,
+		Version:                "",
+		PersistentPreRun:       nil,
+		PersistentPreRunE:      nil,
+		PreRun:                 nil,
+		PreRunE:                nil,
+		Run: func(cmd *cobra.Command, _ []string) {
+			version, _ := cmd.Flags().GetString("version")
+			goos, _ := cmd.Flags().GetString("goos")
+			goarch, _ := cmd.Flags().GetString("goarch")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+
+			err := download.PrintURL(version, goos, goarch, jsonOutput)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+		RunE:               nil,
+		PostRun:            nil,
+		PostRunE:           nil,
+		PersistentPostRun:  nil,
+		PersistentPostRunE: nil,
+		FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: false},
+		CompletionOptions: cobra.CompletionOptions{
+			DisableDefaultCmd:         false,
+			DisableNoDescFlag:         false,
+			DisableDescriptions:       false,
+			HiddenDefaultCmd:          false,
+			DefaultShellCompDirective: nil,
+		},
+		TraverseChildren:           false,
+		Hidden:                     false,
+		SilenceErrors:              false,
+		SilenceUsage:               false,
+		DisableFlagParsing:         false,
+		DisableAutoGenTag:          false,
+		DisableFlagsInUseLine:      false,
+		DisableSuggestions:         false,
+		SuggestionsMinimumDistance: 0,
+	}
+	cmd.Flags().String("version", "", "Go version to resolve (default: latest stable)")
+	cmd.Flags().String("goos", "", "Target operating system (default: current platform)")
+	cmd.Flags().String("goarch", "", "Target architecture (default: current platform)")
+
+	return cmd
+}
@@ -0,0 +1,31 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package url_test provides tests for the url command.
+package url_test
+
+import (
+	"testing"
+
+	"github.com/nicholas-fedor/goUpdater/cmd/url"
+)
+
+func TestNewURLCmd(t *testing.T) {
+	t.Parallel()
+
+	cmd := url.NewURLCmd()
+
+	if cmd.Use != "url" {
+		t.Errorf("Expected command use to be 'url', got %s", cmd.Use)
+	}
+
+	if cmd.Short == "" {
+		t.Error("Expected command to have a short description")
+	}
+
+	for _, name := range []string{"version", "goos", "goarch"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected %s flag to be defined", name)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package use provides the use command for goUpdater.
+// It switches between Go versions installed side by side with install --base-dir.
+package use
+
+import (
+	"os"
+
+	"github.com/nicholas-fedor/goUpdater/internal/install"
+	"github.com/nicholas-fedor/goUpdater/internal/logger"
+	"github.com/nicholas-fedor/goUpdater/internal/privileges"
+	"github.com/spf13/cobra"
+)
+
+// NewUseCmd creates the use command.
+func NewUseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "use <version>",
+		Short: "Switch the active Go version in a versioned base directory",
+		Long: `Switch the active Go version in a base directory populated by 'goUpdater install --base-dir'.
+Each version is installed in its own directory, such as ~/sdk/go1.22.0, and the current symlink in the
+base directory points at the active one; use re-points that symlink atomically. Add <base-dir>/current/bin
+to PATH once and every switch takes effect immediately.`,
+		Aliases:                nil,
+		SuggestFor:             nil,
+		GroupID:                "",
+		Example:                "",
+		ValidArgs:              nil,
+		ValidArgsFunction:      nil,
+		Args:                   cobra.ExactArgs(1),
+		ArgAliases:             nil,
+		BashCompletionFunction: "",
+		Deprecated:             "",
+		Annotations:            nil,
+		Version:                "",
+		PersistentPreRun:       nil,
+		PersistentPreRunE:      nil,
+		PreRun:                 nil,
+		PreRunE:                nil,
+		Run: func(cmd *cobra.Command, args []string) {
+			baseDir, _ := cmd.Flags().GetString("base-dir")
+
+			err := privileges.ElevateIfRequired(baseDir, func() error { return install.Use(baseDir, args[0]) })
+			if err != nil {
+				logger.Errorf("Error switching Go version: %v", err)
+				os.Exit(1)
+			}
+		},
+		RunE:               nil,
+		PostRun:            nil,
+		PostRunE:           nil,
+		PersistentPostRun:  nil,
+		PersistentPostRunE: nil,
+		FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: false},
+		CompletionOptions: cobra.CompletionOptions{
+			DisableDefaultCmd:         false,
+			DisableNoDescFlag:         false,
+			DisableDescriptions:       false,
+			HiddenDefaultCmd:          false,
+			DefaultShellCompDirective: nil,
+		},
+		TraverseChildren:           false,
+		Hidden:                     false,
+		SilenceErrors:              false,
+		SilenceUsage:               false,
+		DisableFlagParsing:         false,
+		DisableAutoGenTag:          false,
+		DisableFlagsInUseLine:      false,
+		DisableSuggestions:         false,
+		SuggestionsMinimumDistance: 0,
+	}
+	cmd.Flags().String("base-dir", "", "Directory holding the versioned Go installations, such as ~/sdk")
+	_ = cmd.MarkFlagRequired("base-dir")
+
+	return cmd
+}
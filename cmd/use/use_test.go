@@ -0,0 +1,47 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package use_test provides tests for the use command.
+package use_test
+
+import (
+	"testing"
+
+	"github.com/nicholas-fedor/goUpdater/cmd/use"
+)
+
+func TestNewUseCmd(t *testing.T) {
+	t.Parallel()
+
+	cmd := use.NewUseCmd()
+
+	if cmd.Use != "use <version>" {
+		t.Errorf("Expected command use to be 'use <version>', got %s", cmd.Use)
+	}
+
+	if cmd.Short == "" || cmd.Long == "" {
+		t.Error("Expected command to have short and long descriptions")
+	}
+
+	if cmd.Run == nil {
+		t.Error("Expected command to have a Run function")
+	}
+
+	if cmd.Args(cmd, nil) == nil {
+		t.Error("Expected a version argument to be required")
+	}
+}
+
+func TestUseCmdRequiresBaseDir(t *testing.T) {
+	t.Parallel()
+
+	cmd := use.NewUseCmd()
+	cmd.SetArgs([]string{"go1.22.0"})
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Error("Expected an error without --base-dir")
+	}
+}
@@ -16,7 +16,10 @@ func NewVerifyCmd() *cobra.Command {
 		Use:   "verify",
 		Short: "Verify the installed Go version",
 		Long: `Verify that Go is properly installed by checking the version.
-Displays the currently installed Go version. By default, checks /usr/local/go.`,
+Displays the currently installed Go version. By default, checks /usr/local/go.
+With --deep, also inspects build information, confirms the pkg/tool binaries are present,
+and compiles a trivial program with the installed toolchain.
+With --all, verifies the install directory and every Go installation under ~/sdk concurrently.`,
 		Aliases:                nil,
 		SuggestFor:             nil,
 		GroupID:                "",
@@ -35,7 +38,23 @@ Displays the currently installed Go version. By default, checks /usr/local/go.`,
 		PreRunE:                nil,
 		Run: func(cmd *cobra.Command, _ []string) {
 			verifyDir, _ := cmd.Flags().GetString("install-dir")
-			verify.Verify(verifyDir)
+			deep, _ := cmd.Flags().GetBool("deep")
+			all, _ := cmd.Flags().GetBool("all")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+
+			if all {
+				verify.VerifyAll(verify.DiscoverInstallations(verifyDir), jsonOutput)
+
+				return
+			}
+
+			if deep {
+				verify.Deep(verifyDir, jsonOutput)
+
+				return
+			}
+
+			verify.Verify(verifyDir, jsonOutput)
 		},
 		RunE:               nil,
 		PostRun:            nil,
@@ -61,6 +80,8 @@ Displays the currently installed Go version. By default, checks /usr/local/go.`,
 		SuggestionsMinimumDistance: 0,
 	}
 	cmd.Flags().StringP("install-dir", "d", "/usr/local/go", "Directory to verify Go installation")
+	cmd.Flags().Bool("deep", false, "Run deeper toolchain checks, including a trivial compile")
+	cmd.Flags().Bool("all", false, "Verify every managed Go installation concurrently")
 
 	return cmd
 }
@@ -17,8 +17,8 @@ import (
 // to stdout in a formatted manner based on the specified output format.
 func NewVersionCmd() *cobra.Command {
 	var (
-		format                           string
-		jsonFlag, shortFlag, verboseFlag bool
+		format                 string
+		shortFlag, verboseFlag bool
 	)
 
 	cmd := &cobra.Command{ //nolint:exhaustruct
@@ -34,13 +34,13 @@ Output formats:
 - short: Only version number
 - verbose: All available information
 - json: JSON formatted output`,
-		Run: func(_ *cobra.Command, _ []string) {
-			version.GetVersion(format, jsonFlag, shortFlag, verboseFlag)
+		Run: func(cmd *cobra.Command, _ []string) {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			version.GetVersion(format, jsonOutput, shortFlag, verboseFlag)
 		},
 	}
 
 	cmd.Flags().StringVar(&format, "format", "", "Output format: default, short, verbose, json")
-	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output in JSON format (shorthand for --format=json)")
 	cmd.Flags().BoolVar(&shortFlag, "short", false, "Output only version number (shorthand for --format=short)")
 	cmd.Flags().BoolVar(&verboseFlag, "verbose", false,
 		"Output all available information (shorthand for --format=verbose)")
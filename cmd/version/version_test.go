@@ -6,11 +6,13 @@ package version_test
 
 import (
 	"os"
+	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/nicholas-fedor/goUpdater/cmd/version"
 	versionpkg "github.com/nicholas-fedor/goUpdater/internal/version"
+	"github.com/spf13/cobra"
 )
 
 func TestNewVersionCmd(t *testing.T) {
@@ -37,10 +39,6 @@ func TestNewVersionCmd(t *testing.T) {
 		t.Error("Expected format flag to be defined")
 	}
 
-	if flags.Lookup("json") == nil {
-		t.Error("Expected json flag to be defined")
-	}
-
 	if flags.Lookup("short") == nil {
 		t.Error("Expected short flag to be defined")
 	}
@@ -96,18 +94,13 @@ func TestVersionCmdFlags(t *testing.T) {
 		t.Errorf("Expected format flag default value to be empty, got %s", formatFlag.DefValue)
 	}
 
-	// Test json flag properties
-	jsonFlag := flags.Lookup("json")
-	if jsonFlag == nil {
-		t.Fatal("json flag not found")
+	// The json flag is the root command's persistent flag, not a local one
+	if flags.Lookup("json") != nil {
+		t.Error("Expected json flag to be inherited from the root command")
 	}
 
 	const defaultFlagValue = "false"
 
-	if jsonFlag.DefValue != defaultFlagValue {
-		t.Errorf("Expected json flag default value to be false, got %s", jsonFlag.DefValue)
-	}
-
 	// Test short flag properties
 	shortFlag := flags.Lookup("short")
 	if shortFlag == nil {
@@ -255,10 +248,10 @@ func TestVersionCmdExecutionJSON(t *testing.T) {
 	versionpkg.SetVersion("1.2.3")
 	versionpkg.SetCommit("abc123")
 
-	cmd := version.NewVersionCmd()
+	cmd := newVersionCmdWithRoot()
 
-	// Set json flag
-	_ = cmd.Flags().Set("json", "true")
+	// Set the inherited json flag
+	_ = cmd.ParseFlags([]string{"--json"})
 
 	// Capture stdout
 	oldStdout := os.Stdout
@@ -326,11 +319,10 @@ func TestVersionCmdFlagPrecedence(t *testing.T) {
 	resetVersionGlobals()
 	versionpkg.SetVersion("1.2.3")
 
-	cmd := version.NewVersionCmd()
+	cmd := newVersionCmdWithRoot()
 
 	// Set format to verbose but json flag to true - json should take precedence
-	_ = cmd.Flags().Set("format", "verbose")
-	_ = cmd.Flags().Set("json", "true")
+	_ = cmd.ParseFlags([]string{"--format", "verbose", "--json"})
 
 	// Capture stdout
 	oldStdout := os.Stdout
@@ -411,7 +403,8 @@ func TestVersionCmdMinimalInfo(t *testing.T) {
 	n, _ := reader.Read(buf)
 	output := string(buf[:n])
 
-	expected := "goUpdater dev\n"
+	// Without ldflags, the Go version still comes from the build info embedded in the test binary.
+	expected := "goUpdater dev\n├─ Go version: " + runtime.Version() + "\n"
 	if output != expected {
 		t.Errorf("Minimal info output = %q, want %q", output, expected)
 	}
@@ -444,6 +437,18 @@ func TestVersionCmdEmptyVersion(t *testing.T) {
 	}
 }
 
+// newVersionCmdWithRoot returns the version command under a parent that defines the persistent
+// --json flag, as the root command does.
+func newVersionCmdWithRoot() *cobra.Command {
+	root := &cobra.Command{Use: "goUpdater"} //nolint:exhaustruct
+	root.PersistentFlags().Bool("json", false, "Print the command's result as JSON")
+
+	cmd := version.NewVersionCmd()
+	root.AddCommand(cmd)
+
+	return cmd
+}
+
 // resetVersionGlobals resets all version global variables for testing.
 func resetVersionGlobals() {
 	versionpkg.SetVersion("")
@@ -7,19 +7,51 @@ package archive
 
 import (
 	"archive/tar"
+	"bufio"
+	"bytes"
 	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
+	"io/fs"
+	"maps"
+	"math"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/nicholas-fedor/goUpdater/internal/logger"
 )
 
 const (
-	defaultDirPerm  = 0755 // Default directory permissions
-	defaultFilePerm = 0644 // Default file permissions
-	unixPermMask    = 0777 // Unix permission mask for tar headers
+	defaultDirPerm  = 0755  // Default directory permissions
+	defaultFilePerm = 0644  // Default file permissions
+	unixPermMask    = 0777  // Unix permission mask for tar headers
+	executablePerm  = 0755  // Canonical permissions for executables
+	executableMask  = 0111  // Any execute permission bit
+	defaultMaxFiles = 50000 // Default limit on archive entries, guarding against zip bombs
+
+	defaultMaxFileSize  = 1 << 30  // Default limit on a single extracted file (1 GiB)
+	defaultMaxTotalSize = 4 << 30  // Default limit on all extracted file contents (4 GiB)
+	defaultBufferSize   = 32 << 10 // Default copy buffer size (32 KiB)
+
+	defaultMaxCompressionRatio = 200 // Default limit on decompressed bytes per archive byte
+
+	zstdMaxWindow = 64 << 20 // Largest zstd window accepted when decoding (64 MiB)
+
+	gzipSizeTrailer = 4 // Bytes of the gzip trailer holding the uncompressed size modulo 4 GiB
 )
 
 // errInvalidPath indicates an invalid file path in the archive.
@@ -31,6 +63,145 @@ var errArchiveNotRegular = errors.New("archive path is not a regular file")
 // errTooManyFiles indicates the archive contains too many files.
 var errTooManyFiles = errors.New("archive contains too many files")
 
+// errFileTooLarge indicates an archive entry exceeds the maximum file size.
+var errFileTooLarge = errors.New("archive entry exceeds maximum file size")
+
+// errArchiveTooLarge indicates the archive contents exceed the maximum total size.
+var errArchiveTooLarge = errors.New("archive contents exceed maximum total size")
+
+// ErrCompressionRatioExceeded indicates the archive decompresses to far more data than its size suggests,
+// as a decompression bomb would.
+var ErrCompressionRatioExceeded = errors.New("archive compression ratio exceeds limit")
+
+// ErrUnsupportedCompression indicates the archive uses a compression format that cannot be decompressed.
+var ErrUnsupportedCompression = errors.New("unsupported archive compression")
+
+// errSizeUnknown indicates the uncompressed size of an archive is not recorded in its compressed stream.
+var errSizeUnknown = errors.New("uncompressed size not recorded")
+
+// Magic bytes identifying the compression format of an archive.
+//
+//nolint:gochecknoglobals
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// errSensitiveLinkTarget indicates a link entry points into a sensitive system directory.
+var errSensitiveLinkTarget = errors.New("link target is in a sensitive directory")
+
+// errInvalidCharacters indicates an archive entry name contains control characters or invalid UTF-8.
+var errInvalidCharacters = errors.New("invalid characters in name")
+
+// SecurityError reports an archive entry rejected by a security validation.
+// Name is the archive entry name and Validation describes the check that failed.
+type SecurityError struct {
+	Name       string
+	Validation string
+	Err        error
+}
+
+// Error returns a human-readable description of the rejected entry.
+func (e *SecurityError) Error() string {
+	return fmt.Sprintf("security validation failed for archive entry %q: %s: %v", e.Name, e.Validation, e.Err)
+}
+
+// Unwrap returns the underlying validation error.
+func (e *SecurityError) Unwrap() error {
+	return e.Err
+}
+
+// MarshalJSON encodes the error as an object with its type, the entry name as member, the failed validation
+// as context, the full message, and the underlying error as cause, so --json output can report it structurally.
+func (e *SecurityError) MarshalJSON() ([]byte, error) {
+	return marshalError(errorJSON{
+		Type:        "security",
+		Message:     e.Error(),
+		ArchivePath: "",
+		Member:      e.Name,
+		Destination: "",
+		Context:     e.Validation,
+		Cause:       errorCause(e.Err),
+	})
+}
+
+// ExtractionError reports a failure to write an archive entry to disk.
+// ArchivePath is the archive being extracted, Member is the archive entry name, and Destination is
+// the path it was being extracted to.
+type ExtractionError struct {
+	ArchivePath string
+	Member      string
+	Destination string
+	Err         error
+}
+
+// Error returns a description naming the archive entry that failed.
+func (e *ExtractionError) Error() string {
+	return fmt.Sprintf("failed to extract archive entry %q to %s: %v", e.Member, e.Destination, e.Err)
+}
+
+// Unwrap returns the underlying filesystem error.
+func (e *ExtractionError) Unwrap() error {
+	return e.Err
+}
+
+// MarshalJSON encodes the error as an object with its type, archive path, member, destination, the full
+// message, and the underlying error as cause, so --json output can report it structurally.
+func (e *ExtractionError) MarshalJSON() ([]byte, error) {
+	return marshalError(errorJSON{
+		Type:        "extraction",
+		Message:     e.Error(),
+		ArchivePath: e.ArchivePath,
+		Member:      e.Member,
+		Destination: e.Destination,
+		Context:     "",
+		Cause:       errorCause(e.Err),
+	})
+}
+
+// errorJSON is the JSON form of SecurityError and ExtractionError. Cause is nested: another errorJSON
+// when the underlying error is one of those types, else an object holding just its message.
+type errorJSON struct {
+	Type        string `json:"type"`
+	Message     string `json:"message"`
+	ArchivePath string `json:"archivePath,omitempty"`
+	Member      string `json:"member,omitempty"`
+	Destination string `json:"destination,omitempty"`
+	Context     string `json:"context,omitempty"`
+	Cause       any    `json:"cause,omitempty"`
+}
+
+// marshalError encodes value, wrapping any encoding failure.
+func marshalError(value errorJSON) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode error: %w", err)
+	}
+
+	return data, nil
+}
+
+// errorCause returns the JSON form of a wrapped error: the error itself when it marshals its own JSON,
+// an object holding its message otherwise, or nil when there is none.
+func errorCause(err error) any {
+	if err == nil {
+		return nil
+	}
+
+	if marshaler, ok := err.(json.Marshaler); ok { //nolint:errorlint // only the direct cause is nested
+		return marshaler
+	}
+
+	return map[string]string{"message": err.Error()}
+}
+
+// PrebuiltPackageExcludes returns exclude globs that skip the prebuilt pkg/<os>_<arch> package archives.
+// Go regenerates these in the build cache on demand, so excluding them slims the installation at the
+// cost of a slower first build. The pkg/tool directory is never excluded.
+func PrebuiltPackageExcludes() []string {
+	return []string{"pkg/*_*"}
+}
+
 // ExtractVersion extracts the Go version from an archive filename.
 // It handles both full paths and filenames by extracting the base name.
 // The function removes the .tar.gz extension if present, then parses the filename
@@ -90,23 +261,246 @@ func Validate(archivePath string) error {
 }
 
 // validateHeaderName checks if the tar header name is safe for extraction.
-// It prevents directory traversal attacks by ensuring no absolute paths or parent directory references.
-func validateHeaderName(headerName string) error {
+// It prevents directory traversal attacks by ensuring no absolute paths or parent directory references,
+// and rejects names containing control characters, including NUL, tab, newline, and DEL.
+// Invalid UTF-8 is also rejected unless allowInvalidUTF8 is set.
+func validateHeaderName(headerName string, allowInvalidUTF8 bool) error {
 	if filepath.IsAbs(headerName) || strings.Contains(headerName, "..") {
-		return fmt.Errorf("invalid file path in archive: %s: %w", headerName, errInvalidPath)
+		return &SecurityError{Name: headerName, Validation: "path traversal", Err: errInvalidPath}
+	}
+
+	for _, char := range headerName {
+		if char < 0x20 || char == 0x7f {
+			return &SecurityError{Name: headerName, Validation: "invalid characters in name", Err: errInvalidCharacters}
+		}
+	}
+
+	if !allowInvalidUTF8 && !utf8.ValidString(headerName) {
+		return &SecurityError{Name: headerName, Validation: "invalid UTF-8 in name", Err: errInvalidCharacters}
+	}
+
+	return nil
+}
+
+// entryWriter holds the state shared by the entries of one extraction.
+// The buffer is used to copy regular file contents; nil allocates one per file. When atomic is set,
+// regular files are written with writeFileAtomic, and when durable is set their contents are synced
+// to disk before they are closed. dirs records the directories already created, so that files sharing
+// a parent directory do not each call os.MkdirAll; nil disables the record.
+type entryWriter struct {
+	buffer  []byte
+	atomic  bool
+	durable bool
+	dirs    map[string]bool
+}
+
+// mkdirAll creates dir and any missing parents, unless the writer already created it.
+func (w *entryWriter) mkdirAll(dir string) error {
+	if w.dirs[dir] {
+		return nil
+	}
+
+	err := os.MkdirAll(dir, defaultDirPerm) // #nosec G301
+	if err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	if w.dirs != nil {
+		for parent := dir; !w.dirs[parent]; parent = filepath.Dir(parent) {
+			w.dirs[parent] = true
+
+			if filepath.Dir(parent) == parent {
+				break
+			}
+		}
 	}
 
 	return nil
 }
 
 // processTarEntry processes a single tar entry, validating and extracting it to the destination directory.
-func processTarEntry(tarReader *tar.Reader, header *tar.Header, destDir string) error {
-	// Validate the header name
-	err := validateHeaderName(header.Name)
+// Validation failures are returned as is; failures while writing the entry are wrapped in an ExtractionError.
+func processTarEntry(
+	tarReader *tar.Reader,
+	header *tar.Header,
+	archivePath, destDir string,
+	writer *entryWriter,
+) error {
+	targetPath, err := entryTargetPath(header, destDir)
+	if err != nil {
+		return err
+	}
+
+	// The name checks above only look at strings; links already written by earlier entries can still
+	// redirect a path out of destDir, so the directories on disk are checked for every entry.
+	err = checkEntrySymlinkFree(header, destDir, targetPath, nil)
+	if err != nil {
+		return err
+	}
+
+	// gosec G305 is triggered by filepath.Join, but we have validated the path thoroughly above
+	// The path is safe because:
+	// 1. header.Name is validated to not contain .. or be absolute
+	// 2. targetPath is checked to be within cleanDestDir
+	// 3. ValidatePath ensures no traversal
+	if header.Typeflag == tar.TypeLink {
+		// Hard link names are relative to the archive root, not to the working directory.
+		err = extractHardLink(targetPath, linkTargetPath(header, destDir))
+	} else {
+		err = extractEntry(tarReader, header, targetPath, writer)
+	}
+
+	if err != nil {
+		return &ExtractionError{ArchivePath: archivePath, Member: entryName(header), Destination: targetPath, Err: err}
+	}
+
+	return nil
+}
+
+// checkEntrySymlinkFree runs checkSymlinkFree for the path of header's entry and, for a hard link, for the
+// path it links to.
+func checkEntrySymlinkFree(header *tar.Header, destDir, targetPath string, symlinks map[string]bool) error {
+	err := checkSymlinkFree(header, destDir, targetPath, symlinks)
+	if err == nil && header.Typeflag == tar.TypeLink {
+		err = checkSymlinkFree(header, destDir, linkTargetPath(header, destDir), symlinks)
+	}
+
+	return err
+}
+
+// checkSymlinkFree checks that no path component below destDir, up to and including targetPath, is a symlink on
+// disk or in symlinks, which holds the paths of symlink entries a dry run has passed without writing them. Each
+// link is validated on its own, but a chain such as "go/l -> .." followed by "go/l2 -> l/.." resolves outside
+// destDir, so an entry written below or onto an extracted symlink could escape. Components that do not exist,
+// or cannot be inspected, are left for the write to report. It returns a SecurityError wrapping errInvalidPath
+// for a path through a symlink.
+func checkSymlinkFree(header *tar.Header, destDir, targetPath string, symlinks map[string]bool) error {
+	cleanDestDir := filepath.Clean(destDir)
+
+	rel, err := filepath.Rel(cleanDestDir, targetPath)
+	if err != nil || !isWithin(targetPath, cleanDestDir) {
+		return &SecurityError{Name: entryName(header), Validation: "path outside destination", Err: errInvalidPath}
+	}
+
+	components := strings.Split(rel, string(filepath.Separator))
+	current := cleanDestDir
+	onDisk := true
+
+	for _, component := range components {
+		current = filepath.Join(current, component)
+
+		if symlinks[current] {
+			return &SecurityError{Name: entryName(header), Validation: "path through symlink " + current, Err: errInvalidPath}
+		}
+
+		if !onDisk {
+			continue
+		}
+
+		info, err := os.Lstat(current)
+		if err != nil {
+			// Missing components, or ones below a regular file, cannot lead through a link on disk;
+			// writing the entry reports the failure.
+			onDisk = false
+
+			continue
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			return &SecurityError{Name: entryName(header), Validation: "path through symlink " + current, Err: errInvalidPath}
+		}
+	}
+
+	return nil
+}
+
+// dryRunEntry makes the checks processTarEntry makes before writing header's entry, treating the symlink
+// entries recorded in symlinks as extracted, and records the entry in symlinks when it is a symlink.
+func dryRunEntry(header *tar.Header, destDir string, symlinks map[string]bool) error {
+	targetPath, err := entryTargetPath(header, destDir)
 	if err != nil {
 		return err
 	}
 
+	err = checkEntrySymlinkFree(header, destDir, targetPath, symlinks)
+	if err != nil {
+		return err
+	}
+
+	if header.Typeflag == tar.TypeSymlink {
+		symlinks[targetPath] = true
+	}
+
+	return nil
+}
+
+// stripComponents validates the entry name and returns a copy of header with its first n path components removed.
+// Hard link targets, which are relative to the archive root, are stripped too; symlink targets are relative to
+// the link itself and stay unchanged. It reports false for entries with no more than n components, which are skipped.
+func stripComponents(header *tar.Header, n int) (*tar.Header, bool, error) {
+	name := entryName(header)
+
+	err := validateHeaderName(name, false)
+	if err != nil {
+		return nil, false, err
+	}
+
+	stripped, ok := stripPath(name, n)
+	if !ok {
+		return nil, false, nil
+	}
+
+	clone := *header
+	clone.Name = stripped
+
+	if _, ok := header.PAXRecords["path"]; ok {
+		clone.PAXRecords = maps.Clone(header.PAXRecords)
+		delete(clone.PAXRecords, "path")
+	}
+
+	if header.Typeflag == tar.TypeLink && !filepath.IsAbs(header.Linkname) {
+		clone.Linkname, ok = stripPath(header.Linkname, n)
+		if !ok {
+			return nil, false, &SecurityError{Name: name, Validation: "hard link target stripped", Err: errInvalidPath}
+		}
+	}
+
+	return &clone, true, nil
+}
+
+// stripPath removes the first n components from the slash-separated name.
+// It reports false when nothing would remain.
+func stripPath(name string, n int) (string, bool) {
+	components := strings.Split(path.Clean(filepath.ToSlash(name)), "/")
+	if len(components) <= n {
+		return "", false
+	}
+
+	return strings.Join(components[n:], "/"), true
+}
+
+// entryName returns the effective path of a tar entry.
+// archive/tar already applies PAX "path" records and GNU long-name entries to header.Name when reading,
+// but the PAX record is preferred explicitly so validation and extraction can never disagree about
+// which name is used, even for headers built or modified outside the reader.
+func entryName(header *tar.Header) string {
+	if paxPath, ok := header.PAXRecords["path"]; ok && paxPath != "" {
+		return paxPath
+	}
+
+	return header.Name
+}
+
+// entryTargetPath validates the tar header name and returns the path it extracts to within destDir.
+func entryTargetPath(header *tar.Header, destDir string) (string, error) {
+	// Validate the header name
+	name := entryName(header)
+
+	err := validateHeaderName(name, false)
+	if err != nil {
+		return "", err
+	}
+
 	// Construct target path safely
 	cleanDestDir := filepath.Clean(destDir)
 	// gosec G305 is triggered by filepath.Join, but we have validated the path thoroughly above
@@ -114,37 +508,32 @@ func processTarEntry(tarReader *tar.Reader, header *tar.Header, destDir string)
 	// 1. header.Name is validated to not contain .. or be absolute
 	// 2. targetPath is checked to be within cleanDestDir
 	// 3. ValidatePath ensures no traversal
-	targetPath := cleanDestDir + string(filepath.Separator) + header.Name
+	targetPath := cleanDestDir + string(filepath.Separator) + name
 	targetPath = filepath.Clean(targetPath)
 
 	// Validate that the target path is within the destination directory
 	if !strings.HasPrefix(targetPath, cleanDestDir+string(filepath.Separator)) && targetPath != cleanDestDir {
-		return fmt.Errorf("invalid file path in archive: %s: %w", targetPath, errInvalidPath)
+		return "", fmt.Errorf("invalid file path in archive: %s: %w", targetPath, errInvalidPath)
 	}
 
 	// Additional validation to prevent path traversal
 	rel, err := filepath.Rel(cleanDestDir, targetPath)
 	if err != nil || strings.HasPrefix(rel, "..") {
-		return fmt.Errorf("invalid file path in archive: %s: %w", targetPath, errInvalidPath)
+		return "", fmt.Errorf("invalid file path in archive: %s: %w", targetPath, errInvalidPath)
 	}
 
 	// Ensure the target path is safe by checking it doesn't escape the destination directory
 	if !strings.HasPrefix(targetPath, cleanDestDir) {
-		return fmt.Errorf("invalid file path in archive: %s: %w", targetPath, errInvalidPath)
+		return "", fmt.Errorf("invalid file path in archive: %s: %w", targetPath, errInvalidPath)
 	}
 
 	// Final safety check: ensure the path is validated before use
 	err = ValidatePath(targetPath, cleanDestDir)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	// gosec G305 is triggered by filepath.Join, but we have validated the path thoroughly above
-	// The path is safe because:
-	// 1. header.Name is validated to not contain .. or be absolute
-	// 2. targetPath is checked to be within cleanDestDir
-	// 3. ValidatePath ensures no traversal
-	return ExtractEntry(tarReader, header, targetPath)
+	return targetPath, nil
 }
 
 // ValidatePath ensures the extracted path is within the installation directory.
@@ -164,12 +553,81 @@ func ValidatePath(targetPath, installDir string) error {
 	return nil
 }
 
+// DefaultSensitivePaths returns the system directories that links in an archive should never point into
+// on the current platform. It is meant for WithSensitivePaths. Broad prefixes such as /usr are left out
+// so that links within a destination like /usr/local/go remain possible.
+func DefaultSensitivePaths() []string {
+	if runtime.GOOS == "windows" {
+		systemRoot := os.Getenv("SystemRoot")
+		if systemRoot == "" {
+			systemRoot = `C:\Windows`
+		}
+
+		return []string{systemRoot}
+	}
+
+	return []string{
+		"/etc", "/bin", "/sbin", "/lib", "/lib64", "/boot", "/dev", "/proc", "/sys",
+		"/usr/bin", "/usr/sbin", "/usr/lib",
+	}
+}
+
+// linkTargetPath returns the path a symlink or hard link entry points to once extracted into destDir.
+// Symlink targets are relative to the link's own directory, while hard link targets are relative to the
+// archive root.
+func linkTargetPath(header *tar.Header, destDir string) string {
+	linkname := filepath.FromSlash(header.Linkname)
+	if filepath.IsAbs(linkname) {
+		return filepath.Clean(linkname)
+	}
+
+	cleanDestDir := filepath.Clean(destDir)
+
+	if header.Typeflag == tar.TypeLink {
+		return filepath.Join(cleanDestDir, linkname)
+	}
+
+	linkDir := filepath.Dir(filepath.Join(cleanDestDir, filepath.FromSlash(entryName(header))))
+
+	return filepath.Join(linkDir, linkname)
+}
+
+// isWithin reports whether targetPath is dir or lies beneath it.
+func isWithin(targetPath, dir string) bool {
+	rel, err := filepath.Rel(dir, targetPath)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// validateLinkname checks that a symlink or hard link entry points inside destDir.
+// When sensitivePaths is non-empty, links pointing into any of those directories are also rejected,
+// as an extra layer on top of the containment check.
+func validateLinkname(header *tar.Header, destDir string, sensitivePaths []string) error {
+	name := entryName(header)
+	target := linkTargetPath(header, destDir)
+
+	if !isWithin(target, filepath.Clean(destDir)) {
+		return &SecurityError{Name: name, Validation: "link target outside destination", Err: errInvalidPath}
+	}
+
+	for _, sensitive := range sensitivePaths {
+		if isWithin(target, filepath.Clean(sensitive)) {
+			return &SecurityError{Name: name, Validation: "link target in " + sensitive, Err: errSensitiveLinkTarget}
+		}
+	}
+
+	return nil
+}
+
 // extractDirectory creates a directory with the specified permissions.
-func extractDirectory(targetPath string, mode os.FileMode) error {
+func extractDirectory(targetPath string, mode os.FileMode, writer *entryWriter) error {
 	// Create directory permissively, then set correct permissions
-	err := os.MkdirAll(targetPath, defaultDirPerm) // #nosec G301
+	err := writer.mkdirAll(filepath.Clean(targetPath))
 	if err != nil {
-		return fmt.Errorf("failed to create directory %s: %w", targetPath, err)
+		return err
 	}
 
 	err = os.Chmod(targetPath, mode)
@@ -180,14 +638,93 @@ func extractDirectory(targetPath string, mode os.FileMode) error {
 	return nil
 }
 
+// WriteFileAtomic writes the contents of reader to path with the given permissions, atomically.
+// The data is written to a temporary file in the same directory and renamed over path only once
+// it is complete, so an interrupted write never leaves a partial file at path. On failure the
+// temporary file is removed and any existing file at path is left untouched.
+func WriteFileAtomic(path string, reader io.Reader, perm os.FileMode) error {
+	return writeFileAtomic(path, reader, perm, nil, false)
+}
+
+// writeFileAtomic implements WriteFileAtomic, copying through buffer; nil allocates one.
+// When durable is set, the temporary file is synced before the rename and the directory after it.
+func writeFileAtomic(path string, reader io.Reader, perm os.FileMode, buffer []byte, durable bool) error {
+	path = filepath.Clean(path)
+
+	tempFile, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for %s: %w", path, err)
+	}
+
+	tempPath := tempFile.Name()
+
+	_, err = io.CopyBuffer(tempFile, reader, buffer)
+	if err == nil {
+		err = tempFile.Chmod(perm)
+	}
+
+	if err == nil && durable {
+		err = tempFile.Sync()
+	}
+
+	closeErr := tempFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tempPath, path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tempPath)
+
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	if durable {
+		return SyncDir(filepath.Dir(path))
+	}
+
+	return nil
+}
+
+// SyncDir flushes the entries of the directory dir to disk, so that files created in or renamed into it
+// survive a crash. It does nothing on Windows, where directories cannot be synced.
+func SyncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	handle, err := os.Open(filepath.Clean(dir))
+	if err != nil {
+		return fmt.Errorf("failed to open directory %s: %w", dir, err)
+	}
+
+	defer func() { _ = handle.Close() }()
+
+	err = handle.Sync()
+	if err != nil {
+		return fmt.Errorf("failed to sync directory %s: %w", dir, err)
+	}
+
+	return nil
+}
+
 // extractRegularFile extracts a regular file from the tar reader.
-func extractRegularFile(tarReader *tar.Reader, targetPath string, mode os.FileMode) error {
+// When writer.atomic is set, the file is written with writeFileAtomic so the final path never holds
+// partial contents.
+func extractRegularFile(tarReader *tar.Reader, targetPath string, mode os.FileMode, writer *entryWriter) error {
 	targetPath = filepath.Clean(targetPath)
 
 	// Ensure parent directory exists
-	err := os.MkdirAll(filepath.Dir(targetPath), defaultDirPerm) // #nosec G301
+	err := writer.mkdirAll(filepath.Dir(targetPath))
 	if err != nil {
-		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(targetPath), err)
+		return err
+	}
+
+	if writer.atomic {
+		return writeFileAtomic(targetPath, tarReader, mode, writer.buffer, writer.durable)
 	}
 
 	// Create file permissively, then set correct permissions
@@ -196,13 +733,22 @@ func extractRegularFile(tarReader *tar.Reader, targetPath string, mode os.FileMo
 		return fmt.Errorf("failed to create file %s: %w", targetPath, err)
 	}
 
-	_, err = io.Copy(file, tarReader)
+	_, err = io.CopyBuffer(file, tarReader, writer.buffer)
 	if err != nil {
 		_ = file.Close()
 
 		return fmt.Errorf("failed to copy file %s: %w", targetPath, err)
 	}
 
+	if writer.durable {
+		err = file.Sync()
+		if err != nil {
+			_ = file.Close()
+
+			return fmt.Errorf("failed to sync file %s: %w", targetPath, err)
+		}
+	}
+
 	err = file.Close()
 	if err != nil {
 		return fmt.Errorf("failed to close file %s: %w", targetPath, err)
@@ -243,15 +789,20 @@ func extractHardLink(targetPath, linkname string) error {
 // It handles directories, regular files, symlinks, and hard links, preserving permissions from the tar header.
 // Files and directories are created permissively then chmod to the correct permissions from header.Mode & 0777.
 func ExtractEntry(tarReader *tar.Reader, header *tar.Header, targetPath string) error {
+	return extractEntry(tarReader, header, targetPath, &entryWriter{buffer: nil, atomic: false, durable: false, dirs: nil})
+}
+
+// extractEntry extracts a single entry from the tar archive using the shared state of writer.
+func extractEntry(tarReader *tar.Reader, header *tar.Header, targetPath string, writer *entryWriter) error {
 	// Extract permissions from tar header, masking to standard Unix permissions
 	mode := os.FileMode(header.Mode & unixPermMask) // #nosec G115
 
 	switch header.Typeflag {
 	case tar.TypeDir:
-		return extractDirectory(targetPath, mode)
+		return extractDirectory(targetPath, mode, writer)
 
 	case tar.TypeReg:
-		return extractRegularFile(tarReader, targetPath, mode)
+		return extractRegularFile(tarReader, targetPath, mode, writer)
 
 	case tar.TypeSymlink:
 		return extractSymlink(targetPath, header.Linkname)
@@ -265,54 +816,557 @@ func ExtractEntry(tarReader *tar.Reader, header *tar.Header, targetPath string)
 	}
 }
 
+// newDecompressor selects a decompressor for the archive stream based on its magic bytes.
+// Gzip and Zstandard archives are supported; anything else fails with ErrUnsupportedCompression.
+// The zstd decoder runs single-threaded with a bounded window so a crafted frame cannot claim
+// unbounded memory, and callers still apply their size and ratio limits to its output.
+func newDecompressor(reader *bufio.Reader) (io.ReadCloser, error) {
+	magic, err := reader.Peek(len(zstdMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read archive header: %w", err)
+	}
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+
+		return gzipReader, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		zstdReader, err := zstd.NewReader(reader,
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderMaxWindow(zstdMaxWindow),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd reader: %w", err)
+		}
+
+		return zstdReader.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("archive is neither gzip- nor zstd-compressed: %w", ErrUnsupportedCompression)
+	}
+}
+
+// UncompressedSize returns the size of the tar stream in a gzip- or zstd-compressed archive without
+// decompressing it, for a disk space check before extraction. The tar stream is slightly larger than the
+// files it holds, and excluded entries are counted too. For gzip, the size is read from the trailer, which
+// holds it modulo 4 GiB and only for the last member, so it assumes a single-member archive below 4 GiB, as
+// Go's release archives are. For zstd, it is the content size recorded in the header of the first frame;
+// an archive whose frame does not record it fails with errSizeUnknown.
+func UncompressedSize(archivePath string) (int64, error) {
+	file, err := os.Open(filepath.Clean(archivePath))
+	if err != nil {
+		return 0, fmt.Errorf("failed to open archive: %w", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	header := make([]byte, zstd.HeaderMaxSize)
+
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return 0, fmt.Errorf("failed to read archive header: %w", err)
+	}
+
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		return gzipUncompressedSize(file)
+	case bytes.HasPrefix(header, zstdMagic):
+		var frame zstd.Header
+
+		err = frame.Decode(header)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read zstd frame header: %w", err)
+		}
+
+		if !frame.HasFCS || frame.FrameContentSize > math.MaxInt64 {
+			return 0, fmt.Errorf("zstd frame: %w", errSizeUnknown)
+		}
+
+		return int64(frame.FrameContentSize), nil
+	default:
+		return 0, fmt.Errorf("archive is neither gzip- nor zstd-compressed: %w", ErrUnsupportedCompression)
+	}
+}
+
+// gzipUncompressedSize reads the uncompressed size from the trailer at the end of a gzip file.
+func gzipUncompressedSize(file *os.File) (int64, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat archive: %w", err)
+	}
+
+	trailer := make([]byte, gzipSizeTrailer)
+
+	_, err = file.ReadAt(trailer, info.Size()-gzipSizeTrailer)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read gzip trailer: %w", err)
+	}
+
+	return int64(binary.LittleEndian.Uint32(trailer)), nil
+}
+
+// isExcluded reports whether an archive entry matches one of the exclude globs.
+// Globs are matched against the entry path relative to the top-level "go/" directory and
+// against each of its parent directories, so "pkg/*_*" excludes everything below pkg/linux_amd64.
+// Entries under bin/ and pkg/tool/ are never excluded because they are required to run Go.
+func isExcluded(headerName string, excludes []string) bool {
+	if len(excludes) == 0 {
+		return false
+	}
+
+	relPath := strings.TrimPrefix(path.Clean(filepath.ToSlash(headerName)), "go/")
+	if relPath == "bin" || strings.HasPrefix(relPath, "bin/") ||
+		relPath == "pkg/tool" || strings.HasPrefix(relPath, "pkg/tool/") {
+		return false
+	}
+
+	for candidate := relPath; candidate != "." && candidate != "/"; candidate = path.Dir(candidate) {
+		for _, pattern := range excludes {
+			matched, err := path.Match(pattern, candidate)
+			if err == nil && matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // Extract extracts the tar.gz archive to the specified destination directory.
 // It validates paths to prevent directory traversal attacks and limits the number of files.
 func Extract(archivePath, destDir string) error {
-	// Validate the archive path before opening
+	return ExtractWithExcludes(archivePath, destDir, nil)
+}
+
+// ExtractWithExcludes extracts the tar.gz archive to the destination directory,
+// skipping entries that match any of the exclude globs (see isExcluded).
+// It validates paths to prevent directory traversal attacks and limits the number of files.
+func ExtractWithExcludes(archivePath, destDir string, excludes []string) error {
+	return NewExtractor(WithExcludes(excludes)).Extract(archivePath, destDir)
+}
+
+// ProgressFunc receives extraction progress after each archive entry is written.
+// filesExtracted counts the entries extracted so far, bytesExtracted sums the sizes
+// of their contents, and currentPath is the archive name of the entry just written.
+type ProgressFunc func(filesExtracted int, bytesExtracted int64, currentPath string)
+
+// Extractor extracts Go archives with configurable behavior.
+// The zero value is not usable; create one with NewExtractor.
+type Extractor struct {
+	maxFiles     int
+	maxFileSize  int64
+	maxTotalSize int64
+	maxRatio     int64
+	bufferSize   int
+	excludes     []string
+	sensitive    []string
+	atomic       bool
+	durable      bool
+	canonical    bool
+	strip        int
+	progress     ProgressFunc
+	progressMu   sync.Mutex
+}
+
+// ExtractorOption configures an Extractor.
+type ExtractorOption func(*Extractor)
+
+// WithMaxFiles limits the number of entries in the archive.
+// Non-positive values keep the default of 50000.
+func WithMaxFiles(maxFiles int) ExtractorOption {
+	return func(e *Extractor) {
+		if maxFiles > 0 {
+			e.maxFiles = maxFiles
+		}
+	}
+}
+
+// WithMaxFileSize limits the size of any single regular file in the archive.
+// Non-positive values keep the default of 1 GiB.
+func WithMaxFileSize(maxFileSize int64) ExtractorOption {
+	return func(e *Extractor) {
+		if maxFileSize > 0 {
+			e.maxFileSize = maxFileSize
+		}
+	}
+}
+
+// WithMaxTotalSize limits the combined size of all regular files in the archive.
+// Non-positive values keep the default of 4 GiB.
+func WithMaxTotalSize(maxTotalSize int64) ExtractorOption {
+	return func(e *Extractor) {
+		if maxTotalSize > 0 {
+			e.maxTotalSize = maxTotalSize
+		}
+	}
+}
+
+// WithMaxCompressionRatio limits how many bytes the archive may decompress to per byte of archive.
+// Extraction stops as soon as the limit is crossed, long before the total size limit for a small bomb.
+// Non-positive values keep the default of 200.
+func WithMaxCompressionRatio(maxRatio int64) ExtractorOption {
+	return func(e *Extractor) {
+		if maxRatio > 0 {
+			e.maxRatio = maxRatio
+		}
+	}
+}
+
+// WithBufferSize sets the size of the buffer used to copy file contents.
+// Non-positive values keep the default of 32 KiB.
+func WithBufferSize(bufferSize int) ExtractorOption {
+	return func(e *Extractor) {
+		if bufferSize > 0 {
+			e.bufferSize = bufferSize
+		}
+	}
+}
+
+// WithExcludes skips archive entries matching any of the given globs (see isExcluded).
+func WithExcludes(excludes []string) ExtractorOption {
+	return func(e *Extractor) {
+		e.excludes = excludes
+	}
+}
+
+// WithSensitivePaths rejects symlinks and hard links that point into any of the given directories,
+// typically DefaultSensitivePaths. Links are always required to stay within the destination directory;
+// this adds a check for destinations that are themselves below a sensitive directory. It is off by default.
+func WithSensitivePaths(paths []string) ExtractorOption {
+	return func(e *Extractor) {
+		e.sensitive = paths
+	}
+}
+
+// WithAtomicWrites writes each regular file to a temporary file and renames it into place (see WriteFileAtomic).
+// This is useful when extracting over an existing installation, where a running binary must never be
+// observed half-written. It is off by default because extraction normally targets a fresh staging directory.
+func WithAtomicWrites(atomic bool) ExtractorOption {
+	return func(e *Extractor) {
+		e.atomic = atomic
+	}
+}
+
+// WithDurableWrites syncs each regular file to disk after it is written and, once extraction completes,
+// every directory it created, so that a crash or power loss right after extraction cannot leave an empty
+// or truncated file behind. It is off by default because syncing makes extraction noticeably slower.
+func WithDurableWrites(durable bool) ExtractorOption {
+	return func(e *Extractor) {
+		e.durable = durable
+	}
+}
+
+// WithCanonicalModes ignores the permission bits recorded in the archive and applies canonical modes instead:
+// 0755 for directories and for files with any execute bit, and 0644 for all other files. Directories created
+// implicitly for an entry's parents are also set to 0755 after extraction, so the resulting tree is the same
+// whatever the process umask or the archive's header bits.
+func WithCanonicalModes(canonical bool) ExtractorOption {
+	return func(e *Extractor) {
+		e.canonical = canonical
+	}
+}
+
+// WithStripComponents removes the first n components from each entry's path before it is extracted, like
+// tar --strip-components. With n = 1 the contents of a Go archive's top-level "go/" directory land directly in
+// the destination, whatever its name. Entries with no more than n components, such as "go/" itself, are skipped.
+// Names are validated before they are stripped. Non-positive values keep paths as they are, the default.
+func WithStripComponents(n int) ExtractorOption {
+	return func(e *Extractor) {
+		e.strip = max(n, 0)
+	}
+}
+
+// WithProgress registers a callback invoked after each archive entry is extracted.
+// Calls are serialized, so the callback does not need its own locking.
+func WithProgress(progress ProgressFunc) ExtractorOption {
+	return func(e *Extractor) {
+		e.progress = progress
+	}
+}
+
+// NewExtractor creates an Extractor with the default limits, applying the given options in order.
+func NewExtractor(options ...ExtractorOption) *Extractor {
+	extractor := &Extractor{
+		maxFiles:     defaultMaxFiles,
+		maxFileSize:  defaultMaxFileSize,
+		maxTotalSize: defaultMaxTotalSize,
+		maxRatio:     defaultMaxCompressionRatio,
+		bufferSize:   defaultBufferSize,
+	}
+
+	for _, option := range options {
+		option(extractor)
+	}
+
+	return extractor
+}
+
+// ExtractSummary describes the entries an extraction writes, or would write during a dry run.
+type ExtractSummary struct {
+	Files      int      `json:"files"`
+	TotalBytes int64    `json:"totalBytes"`
+	EntryTypes []string `json:"entryTypes"`
+}
+
+// Extract extracts the tar.gz archive to the destination directory.
+// It validates paths to prevent directory traversal attacks and enforces the file count and size limits.
+func (e *Extractor) Extract(archivePath, destDir string) error {
+	return e.ExtractContext(context.Background(), archivePath, destDir)
+}
+
+// ExtractContext extracts the archive like Extract, stopping when ctx is cancelled.
+// Cancellation is checked between entries and on every read of file contents,
+// so a large file stops within one buffer of the cancellation and ctx.Err() is returned.
+func (e *Extractor) ExtractContext(ctx context.Context, archivePath, destDir string) error {
+	_, err := e.walk(ctx, archivePath, destDir, walkOptions{include: nil, hash: nil, dryRun: false})
+
+	return err
+}
+
+// ExtractFiltered extracts only the archive entries for which include returns true.
+// Filtered-out entries are skipped before any path construction and do not count toward
+// the file limit; included entries are subject to all the usual validations and size limits.
+func (e *Extractor) ExtractFiltered(archivePath, destDir string, include func(header *tar.Header) bool) error {
+	_, err := e.walk(context.Background(), archivePath, destDir, walkOptions{include: include, hash: nil, dryRun: false})
+
+	return err
+}
+
+// ExtractDryRun validates the archive as Extract would, without writing anything to destDir.
+// Header names, target paths, paths through earlier symlink entries, and the file count and size limits
+// are all checked, so an unsafe archive fails with the same error as a real extraction. Excluded entries
+// are not counted.
+func (e *Extractor) ExtractDryRun(archivePath, destDir string) (*ExtractSummary, error) {
+	return e.walk(context.Background(), archivePath, destDir, walkOptions{include: nil, hash: nil, dryRun: true})
+}
+
+// ValidateArchive checks that archivePath is a regular file and runs every per-entry security check of
+// Extract against destDir: header names, target paths, link targets, and the file count, size, and
+// compression ratio limits. Nothing is written. It returns the first SecurityError or other validation
+// error, so a caller can vet a downloaded archive before, or without, extracting it.
+func (e *Extractor) ValidateArchive(archivePath, destDir string) error {
 	err := Validate(archivePath)
 	if err != nil {
 		return err
 	}
 
+	_, err = e.ExtractDryRun(archivePath, destDir)
+
+	return err
+}
+
+// ExtractAndHash extracts the archive like Extract and returns the hex-encoded SHA-256 of the archive file.
+// The digest is computed from the same read used for extraction, so the archive is read from disk only once.
+// The caller must still compare the digest against the expected checksum and discard the extraction on mismatch.
+func (e *Extractor) ExtractAndHash(archivePath, destDir string) (string, error) {
+	hasher := sha256.New()
+
+	_, err := e.walk(context.Background(), archivePath, destDir, walkOptions{include: nil, hash: hasher, dryRun: false})
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// walkOptions controls how walk processes an archive.
+type walkOptions struct {
+	// include, when non-nil, selects the entries to process; rejected entries are skipped entirely.
+	include func(header *tar.Header) bool
+	// hash, when non-nil, receives every byte of the archive file, including any trailing data.
+	hash hash.Hash
+	// dryRun validates entries without writing anything.
+	dryRun bool
+}
+
+// walk reads the archive entries, validating and extracting each as configured by opts.
+func (e *Extractor) walk(ctx context.Context, archivePath, destDir string, opts walkOptions) (*ExtractSummary, error) {
+	// Validate the archive path before opening
+	err := Validate(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
 	archivePath = filepath.Clean(archivePath)
 
 	file, err := os.Open(archivePath)
 	if err != nil {
-		return fmt.Errorf("failed to open archive: %w", err)
+		return nil, fmt.Errorf("failed to open archive: %w", err)
 	}
 
 	defer func() { _ = file.Close() }()
 
-	gzipReader, err := gzip.NewReader(file)
+	var source io.Reader = file
+	if opts.hash != nil {
+		source = io.TeeReader(file, opts.hash)
+	}
+
+	decompressor, err := newDecompressor(bufio.NewReader(source))
 	if err != nil {
-		return fmt.Errorf("failed to create gzip reader: %w", err)
+		return nil, err
 	}
 
-	defer func() { _ = gzipReader.Close() }()
+	defer func() { _ = decompressor.Close() }()
 
-	tarReader := tar.NewReader(gzipReader)
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat archive: %w", err)
+	}
 
-	// Limit the number of files to prevent zip bomb attacks
-	const maxFiles = 50000
+	limited := &ratioReader{reader: decompressor, limit: ratioLimit(info.Size(), e.maxRatio), read: 0}
+	tarReader := tar.NewReader(&contextReader{ctx: ctx, reader: limited})
 
+	writer := &entryWriter{buffer: nil, atomic: e.atomic, durable: e.durable, dirs: make(map[string]bool)}
+	if !opts.dryRun {
+		writer.buffer = make([]byte, e.bufferSize)
+	}
+
+	summary := &ExtractSummary{Files: 0, TotalBytes: 0, EntryTypes: nil}
 	fileCount := 0
+	skipped := 0
+	roots := make(map[string]bool)
+	symlinks := make(map[string]bool)
 
 	for {
+		err = ctx.Err()
+		if err != nil {
+			return nil, err
+		}
+
 		header, err := tarReader.Next()
 		if errors.Is(err, io.EOF) {
 			break
 		}
 
 		if err != nil {
-			return fmt.Errorf("failed to read tar header: %w", err)
+			return nil, fmt.Errorf("failed to read tar header: %w", err)
+		}
+
+		if opts.include != nil && !opts.include(header) {
+			continue
 		}
 
+		// Limit the number of files to prevent zip bomb attacks
 		fileCount++
-		if fileCount > maxFiles {
-			return fmt.Errorf("archive contains too many files: %w", errTooManyFiles)
+		if fileCount > e.maxFiles {
+			return nil, fmt.Errorf("archive contains too many files: %w", errTooManyFiles)
+		}
+
+		if isExcluded(entryName(header), e.excludes) {
+			skipped++
+
+			continue
+		}
+
+		if e.strip > 0 {
+			var keep bool
+
+			header, keep, err = stripComponents(header, e.strip)
+			if err != nil {
+				return nil, err
+			}
+
+			if !keep {
+				continue
+			}
+		}
+
+		if header.Typeflag == tar.TypeReg {
+			err = e.checkSize(header, summary.TotalBytes)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if header.Typeflag == tar.TypeSymlink || header.Typeflag == tar.TypeLink {
+			err = validateLinkname(header, destDir, e.sensitive)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if e.canonical {
+			header = withCanonicalMode(header)
+			root, _, _ := strings.Cut(entryName(header), "/")
+			roots[root] = true
+		}
+
+		if opts.dryRun {
+			err = dryRunEntry(header, destDir, symlinks)
+		} else {
+			err = processTarEntry(tarReader, header, archivePath, destDir, writer)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		summary.Files++
+
+		if header.Typeflag == tar.TypeReg {
+			summary.TotalBytes += header.Size
+		}
+
+		if entryType := entryTypeName(header.Typeflag); !slices.Contains(summary.EntryTypes, entryType) {
+			summary.EntryTypes = append(summary.EntryTypes, entryType)
+		}
+
+		if !opts.dryRun {
+			e.reportProgress(summary.Files, summary.TotalBytes, entryName(header))
+		}
+	}
+
+	if skipped > 0 {
+		logger.Debugf("Skipped %d excluded archive entries", skipped)
+	}
+
+	if e.canonical && !opts.dryRun {
+		err = canonicalizeDirs(destDir, roots)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if e.durable && !opts.dryRun {
+		err = syncCreatedDirs(destDir, writer.dirs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.hash != nil {
+		// The tar reader stops at the end-of-archive marker; hash any remaining bytes too.
+		_, err = io.Copy(io.Discard, source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+	}
+
+	return summary, nil
+}
+
+// syncCreatedDirs syncs destDir and every directory in dirs below it, so that the entries extracted
+// into them are durable. Parents of destDir recorded by the writer are left alone.
+func syncCreatedDirs(destDir string, dirs map[string]bool) error {
+	destDir = filepath.Clean(destDir)
+
+	err := SyncDir(destDir)
+	if err != nil {
+		return err
+	}
+
+	for dir := range dirs {
+		rel, err := filepath.Rel(destDir, dir)
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
 		}
 
-		err = processTarEntry(tarReader, header, destDir)
+		err = SyncDir(dir)
 		if err != nil {
 			return err
 		}
@@ -320,3 +1374,126 @@ func Extract(archivePath, destDir string) error {
 
 	return nil
 }
+
+// withCanonicalMode returns a copy of header with its mode replaced by the canonical one (see WithCanonicalModes).
+func withCanonicalMode(header *tar.Header) *tar.Header {
+	canonical := *header
+
+	switch {
+	case header.Typeflag == tar.TypeDir, header.Mode&executableMask != 0:
+		canonical.Mode = executablePerm
+	default:
+		canonical.Mode = defaultFilePerm
+	}
+
+	return &canonical
+}
+
+// canonicalizeDirs sets every directory beneath the given top-level entries of destDir to 0755,
+// including parents created implicitly with the umask applied.
+func canonicalizeDirs(destDir string, roots map[string]bool) error {
+	for root := range roots {
+		rootPath := filepath.Join(destDir, root)
+
+		err := filepath.WalkDir(rootPath, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || !entry.IsDir() {
+				return err
+			}
+
+			return os.Chmod(path, executablePerm)
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to set directory permissions under %s: %w", rootPath, err)
+		}
+	}
+
+	return nil
+}
+
+// ratioLimit returns the decompressed size limit for an archive of size compressed bytes, saturating at
+// math.MaxInt64 instead of overflowing for a large archive or ratio.
+func ratioLimit(size, maxRatio int64) int64 {
+	size = max(size, 1)
+	if size > math.MaxInt64/maxRatio {
+		return math.MaxInt64
+	}
+
+	return size * maxRatio
+}
+
+// ratioReader is an io.Reader that fails with ErrCompressionRatioExceeded once more than limit bytes are read.
+type ratioReader struct {
+	reader io.Reader
+	limit  int64
+	read   int64
+}
+
+// Read reads from the underlying reader, enforcing the decompressed size limit.
+func (r *ratioReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+
+	r.read += int64(n)
+	if r.read > r.limit {
+		return n, fmt.Errorf("decompressed more than %d bytes: %w", r.limit, ErrCompressionRatioExceeded)
+	}
+
+	return n, err
+}
+
+// contextReader is an io.Reader that fails with the context's error once the context is done.
+type contextReader struct {
+	ctx    context.Context //nolint:containedctx // scoped to a single extraction
+	reader io.Reader
+}
+
+// Read reads from the underlying reader unless the context is done.
+func (r *contextReader) Read(p []byte) (int, error) {
+	err := r.ctx.Err()
+	if err != nil {
+		return 0, err
+	}
+
+	return r.reader.Read(p)
+}
+
+// entryTypeName returns a readable name for a tar entry type.
+func entryTypeName(typeflag byte) string {
+	switch typeflag {
+	case tar.TypeDir:
+		return "directory"
+	case tar.TypeReg:
+		return "file"
+	case tar.TypeSymlink:
+		return "symlink"
+	case tar.TypeLink:
+		return "hardlink"
+	default:
+		return fmt.Sprintf("unsupported (%q)", typeflag)
+	}
+}
+
+// checkSize enforces the per-file and total size limits for a regular file entry.
+// The tar reader never yields more than header.Size bytes, so checking the header is sufficient.
+func (e *Extractor) checkSize(header *tar.Header, bytesExtracted int64) error {
+	if header.Size > e.maxFileSize {
+		return fmt.Errorf("%s is %d bytes, limit is %d: %w", header.Name, header.Size, e.maxFileSize, errFileTooLarge)
+	}
+
+	if bytesExtracted+header.Size > e.maxTotalSize {
+		return fmt.Errorf("extracting %s would exceed %d bytes: %w", header.Name, e.maxTotalSize, errArchiveTooLarge)
+	}
+
+	return nil
+}
+
+// reportProgress invokes the progress callback, if any, serializing concurrent calls.
+func (e *Extractor) reportProgress(filesExtracted int, bytesExtracted int64, currentPath string) {
+	if e.progress == nil {
+		return
+	}
+
+	e.progressMu.Lock()
+	defer e.progressMu.Unlock()
+
+	e.progress(filesExtracted, bytesExtracted, currentPath)
+}
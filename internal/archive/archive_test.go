@@ -4,8 +4,23 @@
 package archive
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"testing/iotest"
+
+	"github.com/klauspost/compress/zstd"
 )
 
 const goVersionPrefix = "go1"
@@ -224,6 +239,1498 @@ func TestExtractVersion_EdgeCases(t *testing.T) {
 	})
 }
 
+// testEntry describes an entry written to a test archive.
+// For symlinks and hard links, content is the link target instead of the entry's contents.
+type testEntry struct {
+	name     string
+	typeflag byte
+	content  string
+}
+
+// createTestArchive writes a tar.gz archive containing the given entries and returns its path.
+func createTestArchive(t *testing.T, entries []testEntry) string {
+	t.Helper()
+
+	return createCompressedTestArchive(t, "go1.21.0.linux-amd64.tar.gz", entries,
+		func(w io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(w), nil })
+}
+
+// createZstdTestArchive creates a zstd-compressed tar archive with the given entries for testing.
+func createZstdTestArchive(t *testing.T, entries []testEntry) string {
+	t.Helper()
+
+	return createCompressedTestArchive(t, "go1.21.0.linux-amd64.tar.zst", entries,
+		func(w io.Writer) (io.WriteCloser, error) { return zstd.NewWriter(w) })
+}
+
+// createCompressedTestArchive writes entries as a tar stream through the compressor returned by newWriter.
+func createCompressedTestArchive(
+	t *testing.T,
+	name string,
+	entries []testEntry,
+	newWriter func(io.Writer) (io.WriteCloser, error),
+) string {
+	t.Helper()
+
+	archivePath := filepath.Join(t.TempDir(), name)
+
+	file, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	compressor, err := newWriter(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tarWriter := tar.NewWriter(compressor)
+
+	for _, entry := range entries {
+		header := &tar.Header{
+			Name:     entry.name,
+			Typeflag: entry.typeflag,
+			Mode:     0755,
+			Size:     int64(len(entry.content)),
+		}
+
+		if entry.typeflag == tar.TypeSymlink || entry.typeflag == tar.TypeLink {
+			header.Linkname, header.Size, entry.content = entry.content, 0, ""
+		}
+
+		err = tarWriter.WriteHeader(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = tarWriter.Write([]byte(entry.content))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, closer := range []interface{ Close() error }{tarWriter, compressor, file} {
+		err = closer.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return archivePath
+}
+
+func TestExtractSkipsUnsupportedEntries(t *testing.T) {
+	t.Parallel()
+
+	// Unsupported entries are skipped without being created, and the entries after them
+	// are still read from the right offset.
+	archivePath := createTestArchive(t, []testEntry{
+		{name: "go/", typeflag: tar.TypeDir, content: ""},
+		{name: "go/dev/null", typeflag: tar.TypeChar, content: ""},
+		{name: "go/fifo", typeflag: tar.TypeFifo, content: ""},
+		{name: "go/bin/go", typeflag: tar.TypeReg, content: "go binary"},
+	})
+	destDir := t.TempDir()
+
+	err := Extract(archivePath, destDir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(destDir, "go", "bin", "go"))
+	if err != nil {
+		t.Fatalf("regular file after the device entry was not extracted: %v", err)
+	}
+
+	if string(content) != "go binary" {
+		t.Errorf("go/bin/go = %q, want %q", content, "go binary")
+	}
+
+	for _, unsupported := range []string{"dev/null", "fifo"} {
+		_, err = os.Lstat(filepath.Join(destDir, "go", unsupported))
+		if !os.IsNotExist(err) {
+			t.Errorf("unsupported entry go/%s was created: %v", unsupported, err)
+		}
+	}
+}
+
+func TestExtractWithExcludes(t *testing.T) {
+	t.Parallel()
+
+	archivePath := createTestArchive(t, []testEntry{
+		{name: "go/", typeflag: tar.TypeDir, content: ""},
+		{name: "go/bin/", typeflag: tar.TypeDir, content: ""},
+		{name: "go/bin/go", typeflag: tar.TypeReg, content: "go binary"},
+		{name: "go/pkg/", typeflag: tar.TypeDir, content: ""},
+		{name: "go/pkg/tool/linux_amd64/compile", typeflag: tar.TypeReg, content: "compiler"},
+		{name: "go/pkg/linux_amd64/", typeflag: tar.TypeDir, content: ""},
+		{name: "go/pkg/linux_amd64/fmt.a", typeflag: tar.TypeReg, content: "package archive"},
+		{name: "go/pkg/include/asm.h", typeflag: tar.TypeReg, content: "header"},
+	})
+
+	destDir := t.TempDir()
+
+	err := ExtractWithExcludes(archivePath, destDir, PrebuiltPackageExcludes())
+	if err != nil {
+		t.Fatalf("ExtractWithExcludes() unexpected error: %v", err)
+	}
+
+	for _, kept := range []string{"go/bin/go", "go/pkg/tool/linux_amd64/compile", "go/pkg/include/asm.h"} {
+		_, err = os.Stat(filepath.Join(destDir, kept))
+		if err != nil {
+			t.Errorf("expected %s to be extracted: %v", kept, err)
+		}
+	}
+
+	_, err = os.Stat(filepath.Join(destDir, "go", "pkg", "linux_amd64"))
+	if !os.IsNotExist(err) {
+		t.Errorf("expected go/pkg/linux_amd64 to be skipped, got err = %v", err)
+	}
+}
+
+func TestExtract_UnsupportedCompression(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content []byte
+	}{
+		{name: "plain text", content: []byte("not an archive")},
+		{name: "empty", content: nil},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			archivePath := filepath.Join(t.TempDir(), "go1.23.0.linux-amd64.tar.zst")
+
+			err := os.WriteFile(archivePath, testCase.content, 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = Extract(archivePath, t.TempDir())
+			if !errors.Is(err, ErrUnsupportedCompression) {
+				t.Errorf("Extract() error = %v, want ErrUnsupportedCompression", err)
+			}
+		})
+	}
+}
+
+func TestUncompressedSize(t *testing.T) {
+	t.Parallel()
+
+	var tarStream bytes.Buffer
+
+	tarWriter := tar.NewWriter(&tarStream)
+
+	err := tarWriter.WriteHeader(&tar.Header{Name: "go/VERSION", Typeflag: tar.TypeReg, Mode: 0644, Size: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = tarWriter.Write([]byte("go1.21.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		compress func(w io.Writer) (io.WriteCloser, error)
+		want     int64
+		wantErr  error
+	}{
+		{
+			name:     "gzip",
+			compress: func(w io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(w), nil },
+			want:     int64(tarStream.Len()),
+			wantErr:  nil,
+		},
+		{
+			name: "zstd with content size",
+			compress: func(w io.Writer) (io.WriteCloser, error) {
+				return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1), zstd.WithSingleSegment(true))
+			},
+			want:    int64(tarStream.Len()),
+			wantErr: nil,
+		},
+		{
+			name: "zstd without content size",
+			compress: func(w io.Writer) (io.WriteCloser, error) {
+				encoder, err := zstd.NewWriter(w)
+
+				return flushingWriter{Encoder: encoder}, err
+			},
+			want:    0,
+			wantErr: errSizeUnknown,
+		},
+		{
+			name:     "uncompressed tar",
+			compress: func(w io.Writer) (io.WriteCloser, error) { return nopWriteCloser{Writer: w}, nil },
+			want:     0,
+			wantErr:  ErrUnsupportedCompression,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var compressed bytes.Buffer
+
+			compressor, err := testCase.compress(&compressed)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = compressor.Write(tarStream.Bytes())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = compressor.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			archivePath := filepath.Join(t.TempDir(), "archive")
+
+			err = os.WriteFile(archivePath, compressed.Bytes(), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			size, err := UncompressedSize(archivePath)
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("UncompressedSize() error = %v, want %v", err, testCase.wantErr)
+			}
+
+			if size != testCase.want {
+				t.Errorf("UncompressedSize() = %d, want %d", size, testCase.want)
+			}
+		})
+	}
+}
+
+// nopWriteCloser adds a no-op Close to an io.Writer.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// flushingWriter flushes a zstd encoder after every write, so the frame is started before its size is known
+// and does not record it.
+type flushingWriter struct {
+	*zstd.Encoder
+}
+
+func (w flushingWriter) Write(p []byte) (int, error) {
+	n, err := w.Encoder.Write(p)
+	if err != nil {
+		return n, err
+	}
+
+	return n, w.Flush()
+}
+
+func TestExtract_Zstd(t *testing.T) {
+	t.Parallel()
+
+	archivePath := createZstdTestArchive(t, []testEntry{
+		{name: "go/", typeflag: tar.TypeDir, content: ""},
+		{name: "go/VERSION", typeflag: tar.TypeReg, content: "go1.21.0"},
+		{name: "go/bin/go", typeflag: tar.TypeReg, content: "go binary"},
+	})
+	destDir := t.TempDir()
+
+	err := Extract(archivePath, destDir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(destDir, "go", "bin", "go"))
+	if err != nil || string(content) != "go binary" {
+		t.Errorf("go/bin/go = %q, %v, want %q", content, err, "go binary")
+	}
+}
+
+func TestExtract_CorruptZstd(t *testing.T) {
+	t.Parallel()
+
+	archivePath := filepath.Join(t.TempDir(), "go1.21.0.linux-amd64.tar.zst")
+
+	// Valid zstd magic followed by a truncated frame header.
+	err := os.WriteFile(archivePath, []byte{0x28, 0xb5, 0x2f, 0xfd, 0x00, 0x00}, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Extract(archivePath, t.TempDir())
+	if err == nil {
+		t.Error("Extract() error = nil, want a zstd decoding error")
+	}
+}
+
+func TestExtractor_Progress(t *testing.T) {
+	t.Parallel()
+
+	// A name past the 100-byte ustar limit is stored in a PAX record and must be reported in full.
+	longName := "go/src/" + strings.Repeat("long/", 25) + "file.go"
+	archivePath := createTestArchive(t, []testEntry{
+		{name: "go/", typeflag: tar.TypeDir, content: ""},
+		{name: "go/VERSION", typeflag: tar.TypeReg, content: "go1.21.0"},
+		{name: "go/bin/go", typeflag: tar.TypeReg, content: "go binary"},
+		{name: longName, typeflag: tar.TypeReg, content: "long"},
+	})
+
+	var (
+		paths     []string
+		lastFiles int
+		lastBytes int64
+	)
+
+	extractor := NewExtractor(WithProgress(func(filesExtracted int, bytesExtracted int64, currentPath string) {
+		paths = append(paths, currentPath)
+		lastFiles = filesExtracted
+		lastBytes = bytesExtracted
+	}))
+
+	err := extractor.Extract(archivePath, t.TempDir())
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	wantPaths := []string{"go/", "go/VERSION", "go/bin/go", longName}
+	if strings.Join(paths, ",") != strings.Join(wantPaths, ",") {
+		t.Errorf("progress paths = %v, want %v", paths, wantPaths)
+	}
+
+	if lastFiles != 4 {
+		t.Errorf("filesExtracted = %d, want 4", lastFiles)
+	}
+
+	if wantBytes := int64(len("go1.21.0") + len("go binary") + len("long")); lastBytes != wantBytes {
+		t.Errorf("bytesExtracted = %d, want %d", lastBytes, wantBytes)
+	}
+}
+
+func TestNewExtractor_Options(t *testing.T) {
+	t.Parallel()
+
+	defaults := NewExtractor()
+	if defaults.maxFiles != defaultMaxFiles || defaults.maxFileSize != defaultMaxFileSize ||
+		defaults.maxTotalSize != defaultMaxTotalSize || defaults.bufferSize != defaultBufferSize {
+		t.Errorf("NewExtractor() did not apply the default limits: %+v", defaults)
+	}
+
+	tuned := NewExtractor(WithMaxFiles(10), WithMaxFileSize(20), WithMaxTotalSize(30), WithBufferSize(40))
+	if tuned.maxFiles != 10 || tuned.maxFileSize != 20 || tuned.maxTotalSize != 30 || tuned.bufferSize != 40 {
+		t.Errorf("NewExtractor() did not apply the options: %+v", tuned)
+	}
+
+	fallback := NewExtractor(WithMaxFiles(0), WithMaxFileSize(-1), WithMaxTotalSize(0), WithBufferSize(-1))
+	if fallback.maxFiles != defaultMaxFiles || fallback.maxFileSize != defaultMaxFileSize ||
+		fallback.maxTotalSize != defaultMaxTotalSize || fallback.bufferSize != defaultBufferSize {
+		t.Errorf("NewExtractor() did not fall back to the defaults: %+v", fallback)
+	}
+}
+
+func TestExtractor_Limits(t *testing.T) {
+	t.Parallel()
+
+	archivePath := createTestArchive(t, []testEntry{
+		{name: "go/", typeflag: tar.TypeDir, content: ""},
+		{name: "go/a", typeflag: tar.TypeReg, content: "0123456789"},
+		{name: "go/b", typeflag: tar.TypeReg, content: "0123456789"},
+	})
+
+	tests := []struct {
+		name    string
+		options []ExtractorOption
+		wantErr error
+	}{
+		{name: "within limits", options: []ExtractorOption{WithBufferSize(1)}, wantErr: nil},
+		{name: "too many files", options: []ExtractorOption{WithMaxFiles(2)}, wantErr: errTooManyFiles},
+		{name: "file too large", options: []ExtractorOption{WithMaxFileSize(9)}, wantErr: errFileTooLarge},
+		{name: "total too large", options: []ExtractorOption{WithMaxTotalSize(15)}, wantErr: errArchiveTooLarge},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := NewExtractor(testCase.options...).Extract(archivePath, t.TempDir())
+			if !errors.Is(err, testCase.wantErr) {
+				t.Errorf("Extract() error = %v, want %v", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestExtractor_ExtractDryRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("safe archive", func(t *testing.T) {
+		t.Parallel()
+
+		archivePath := createTestArchive(t, []testEntry{
+			{name: "go/", typeflag: tar.TypeDir, content: ""},
+			{name: "go/VERSION", typeflag: tar.TypeReg, content: "go1.21.0"},
+			{name: "go/bin/go", typeflag: tar.TypeReg, content: "go binary"},
+		})
+		destDir := t.TempDir()
+
+		summary, err := NewExtractor().ExtractDryRun(archivePath, destDir)
+		if err != nil {
+			t.Fatalf("ExtractDryRun() error = %v", err)
+		}
+
+		if summary.Files != 3 || summary.TotalBytes != int64(len("go1.21.0")+len("go binary")) {
+			t.Errorf("ExtractDryRun() = %+v, want 3 files and 17 bytes", summary)
+		}
+
+		if strings.Join(summary.EntryTypes, ",") != "directory,file" {
+			t.Errorf("EntryTypes = %v, want [directory file]", summary.EntryTypes)
+		}
+
+		entries, err := os.ReadDir(destDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(entries) != 0 {
+			t.Errorf("ExtractDryRun() wrote %d entries, want none", len(entries))
+		}
+	})
+
+	t.Run("malicious archive", func(t *testing.T) {
+		t.Parallel()
+
+		archivePath := createTestArchive(t, []testEntry{
+			{name: "go/../../etc/passwd", typeflag: tar.TypeReg, content: "root"},
+		})
+
+		_, dryRunErr := NewExtractor().ExtractDryRun(archivePath, t.TempDir())
+		extractErr := NewExtractor().Extract(archivePath, t.TempDir())
+
+		var securityErr *SecurityError
+		if !errors.As(dryRunErr, &securityErr) {
+			t.Fatalf("ExtractDryRun() error = %v, want SecurityError", dryRunErr)
+		}
+
+		if extractErr == nil || dryRunErr.Error() != extractErr.Error() {
+			t.Errorf("ExtractDryRun() error = %v, Extract() error = %v, want the same", dryRunErr, extractErr)
+		}
+	})
+}
+
+func TestExtractor_ValidateArchive(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		entries      []testEntry
+		wantSecurity bool
+	}{
+		{
+			name: "safe archive",
+			entries: []testEntry{
+				{name: "go/", typeflag: tar.TypeDir, content: ""},
+				{name: "go/bin/go", typeflag: tar.TypeReg, content: "go binary"},
+			},
+			wantSecurity: false,
+		},
+		{
+			name:         "path traversal",
+			entries:      []testEntry{{name: "go/../../etc/passwd", typeflag: tar.TypeReg, content: "root"}},
+			wantSecurity: true,
+		},
+		{
+			name: "file through symlink",
+			entries: []testEntry{
+				{name: "go/", typeflag: tar.TypeDir, content: ""},
+				{name: "go/l", typeflag: tar.TypeSymlink, content: ".."},
+				{name: "go/l2", typeflag: tar.TypeSymlink, content: "l/.."},
+				{name: "go/l2/pwned", typeflag: tar.TypeReg, content: "pwned"},
+			},
+			wantSecurity: true,
+		},
+		{
+			name: "file onto symlink",
+			entries: []testEntry{
+				{name: "go/", typeflag: tar.TypeDir, content: ""},
+				{name: "go/l", typeflag: tar.TypeSymlink, content: "bin"},
+				{name: "go/l", typeflag: tar.TypeReg, content: "pwned"},
+			},
+			wantSecurity: true,
+		},
+		{
+			name: "hard link through symlink",
+			entries: []testEntry{
+				{name: "go/", typeflag: tar.TypeDir, content: ""},
+				{name: "go/l", typeflag: tar.TypeSymlink, content: "."},
+				{name: "go/h", typeflag: tar.TypeLink, content: "go/l/bin"},
+			},
+			wantSecurity: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			archivePath := createTestArchive(t, testCase.entries)
+			destDir := t.TempDir()
+
+			err := NewExtractor().ValidateArchive(archivePath, destDir)
+
+			extractErr := Extract(archivePath, t.TempDir())
+			if (err == nil) != (extractErr == nil) {
+				t.Errorf("ValidateArchive() error = %v, Extract() error = %v, want both to agree", err, extractErr)
+			}
+
+			var securityErr *SecurityError
+			if errors.As(err, &securityErr) != testCase.wantSecurity || !testCase.wantSecurity && err != nil {
+				t.Fatalf("ValidateArchive() error = %v, want SecurityError: %t", err, testCase.wantSecurity)
+			}
+
+			entries, err := os.ReadDir(destDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(entries) != 0 {
+				t.Errorf("ValidateArchive() wrote %d entries, want none", len(entries))
+			}
+		})
+	}
+
+	t.Run("not a regular file", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewExtractor().ValidateArchive(t.TempDir(), t.TempDir())
+		if !errors.Is(err, errArchiveNotRegular) {
+			t.Errorf("ValidateArchive() error = %v, want %v", err, errArchiveNotRegular)
+		}
+	})
+}
+
+func TestExtractor_ExtractContext(t *testing.T) {
+	t.Parallel()
+
+	archivePath := createTestArchive(t, []testEntry{
+		{name: "go/", typeflag: tar.TypeDir, content: ""},
+		{name: "go/VERSION", typeflag: tar.TypeReg, content: "go1.21.0"},
+		{name: "go/bin/go", typeflag: tar.TypeReg, content: "go binary"},
+	})
+
+	t.Run("cancelled before start", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := NewExtractor().ExtractContext(ctx, archivePath, t.TempDir())
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("ExtractContext() error = %v, want context.Canceled", err)
+		}
+	})
+
+	t.Run("cancelled mid-extraction", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		destDir := t.TempDir()
+		extractor := NewExtractor(WithProgress(func(_ int, _ int64, _ string) { cancel() }))
+
+		err := extractor.ExtractContext(ctx, archivePath, destDir)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("ExtractContext() error = %v, want context.Canceled", err)
+		}
+
+		_, err = os.Stat(filepath.Join(destDir, "go", "bin", "go"))
+		if !os.IsNotExist(err) {
+			t.Errorf("entries after cancellation should not be extracted, stat error = %v", err)
+		}
+	})
+}
+
+func TestExtract_ExtractionErrorNamesMember(t *testing.T) {
+	t.Parallel()
+
+	// go/VERSION is a regular file, so creating go/VERSION/nested fails.
+	archivePath := createTestArchive(t, []testEntry{
+		{name: "go/VERSION", typeflag: tar.TypeReg, content: "go1.21.0"},
+		{name: "go/VERSION/nested", typeflag: tar.TypeReg, content: "nested"},
+	})
+
+	err := Extract(archivePath, t.TempDir())
+
+	var extractionErr *ExtractionError
+	if !errors.As(err, &extractionErr) {
+		t.Fatalf("Extract() error = %v, want ExtractionError", err)
+	}
+
+	if extractionErr.Member != "go/VERSION/nested" {
+		t.Errorf("Member = %q, want %q", extractionErr.Member, "go/VERSION/nested")
+	}
+
+	if !strings.Contains(err.Error(), "go/VERSION/nested") {
+		t.Errorf("Error() = %q, want it to name the archive entry", err.Error())
+	}
+}
+
+func TestExtractionError_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	archivePath := createTestArchive(t, []testEntry{
+		{name: "go/VERSION", typeflag: tar.TypeReg, content: "go1.21.0"},
+		{name: "go/VERSION/nested", typeflag: tar.TypeReg, content: "nested"},
+	})
+
+	err := Extract(archivePath, t.TempDir())
+
+	var extractionErr *ExtractionError
+	if !errors.As(err, &extractionErr) {
+		t.Fatalf("Extract() error = %v, want ExtractionError", err)
+	}
+
+	data, err := json.Marshal(extractionErr)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var decoded map[string]any
+
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		t.Fatalf("MarshalJSON() produced invalid JSON %s: %v", data, err)
+	}
+
+	want := map[string]any{
+		"type":        "extraction",
+		"archivePath": archivePath,
+		"member":      "go/VERSION/nested",
+		"destination": extractionErr.Destination,
+		"message":     extractionErr.Error(),
+	}
+	for key, value := range want {
+		if decoded[key] != value {
+			t.Errorf("%s = %v, want %v", key, decoded[key], value)
+		}
+	}
+
+	cause, ok := decoded["cause"].(map[string]any)
+	if !ok || cause["message"] != extractionErr.Err.Error() {
+		t.Errorf("cause = %v, want the message of %v", decoded["cause"], extractionErr.Err)
+	}
+}
+
+func TestSecurityError_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	wrapped := &ExtractionError{
+		ArchivePath: "go.tar.gz",
+		Member:      "go/link",
+		Destination: "/tmp/go/link",
+		Err:         &SecurityError{Name: "go/link", Validation: "link target outside destination", Err: errInvalidPath},
+	}
+
+	data, err := json.Marshal(wrapped)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var decoded struct {
+		Cause struct {
+			Type    string `json:"type"`
+			Member  string `json:"member"`
+			Context string `json:"context"`
+			Cause   struct {
+				Message string `json:"message"`
+			} `json:"cause"`
+		} `json:"cause"`
+	}
+
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		t.Fatalf("MarshalJSON() produced invalid JSON %s: %v", data, err)
+	}
+
+	cause := decoded.Cause
+	if cause.Type != "security" || cause.Member != "go/link" || cause.Context != "link target outside destination" ||
+		cause.Cause.Message != errInvalidPath.Error() {
+		t.Errorf("nested SecurityError = %+v, want it encoded with its own fields", cause)
+	}
+}
+
+func TestExtractor_CompressionRatio(t *testing.T) {
+	t.Parallel()
+
+	// A megabyte of zeros compresses roughly a thousandfold, well past the default ratio.
+	entries := []testEntry{
+		{name: "go/zeros", typeflag: tar.TypeReg, content: strings.Repeat("\x00", 1<<20)},
+	}
+	gzipPath := createTestArchive(t, entries)
+	zstdPath := createZstdTestArchive(t, entries)
+	raised := []ExtractorOption{WithMaxCompressionRatio(1 << 20)}
+	unlimited := []ExtractorOption{WithMaxCompressionRatio(math.MaxInt64)}
+
+	tests := []struct {
+		name        string
+		archivePath string
+		options     []ExtractorOption
+		wantErr     error
+	}{
+		{name: "default ratio rejects bomb", archivePath: gzipPath, options: nil, wantErr: ErrCompressionRatioExceeded},
+		{name: "raised ratio allows archive", archivePath: gzipPath, options: raised, wantErr: nil},
+		{name: "default ratio rejects zstd bomb", archivePath: zstdPath, options: nil, wantErr: ErrCompressionRatioExceeded},
+		{name: "raised ratio allows zstd archive", archivePath: zstdPath, options: raised, wantErr: nil},
+		{name: "unlimited ratio allows archive", archivePath: gzipPath, options: unlimited, wantErr: nil},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := NewExtractor(testCase.options...).Extract(testCase.archivePath, t.TempDir())
+			if !errors.Is(err, testCase.wantErr) {
+				t.Errorf("Extract() error = %v, want %v", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestRatioLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		size     int64
+		maxRatio int64
+		want     int64
+	}{
+		{name: "product", size: 1024, maxRatio: 100, want: 102400},
+		{name: "empty archive counts as one byte", size: 0, maxRatio: 100, want: 100},
+		{name: "large ratio saturates", size: 1024, maxRatio: math.MaxInt64, want: math.MaxInt64},
+		{name: "large archive saturates", size: math.MaxInt64 / 2, maxRatio: 3, want: math.MaxInt64},
+		{name: "exact fit", size: math.MaxInt64 / 7, maxRatio: 7, want: math.MaxInt64 / 7 * 7},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ratioLimit(testCase.size, testCase.maxRatio)
+			if got != testCase.want {
+				t.Errorf("ratioLimit(%d, %d) = %d, want %d", testCase.size, testCase.maxRatio, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_ExtractFiltered(t *testing.T) {
+	t.Parallel()
+
+	archivePath := createTestArchive(t, []testEntry{
+		{name: "go/", typeflag: tar.TypeDir, content: ""},
+		{name: "go/VERSION", typeflag: tar.TypeReg, content: "go1.21.0"},
+		{name: "go/bin/go", typeflag: tar.TypeReg, content: "go binary"},
+		{name: "go/bin/gofmt", typeflag: tar.TypeReg, content: "gofmt binary"},
+	})
+	destDir := t.TempDir()
+
+	// A limit of one file would fail a full extraction, but filtered-out entries do not count.
+	err := NewExtractor(WithMaxFiles(1)).ExtractFiltered(archivePath, destDir, func(header *tar.Header) bool {
+		return header.Name == "go/bin/go"
+	})
+	if err != nil {
+		t.Fatalf("ExtractFiltered() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(destDir, "go", "bin", "go"))
+	if err != nil || string(content) != "go binary" {
+		t.Errorf("go/bin/go = %q, %v, want %q", content, err, "go binary")
+	}
+
+	for _, name := range []string{"VERSION", filepath.Join("bin", "gofmt")} {
+		_, err = os.Stat(filepath.Join(destDir, "go", name))
+		if !os.IsNotExist(err) {
+			t.Errorf("%s should not be extracted, stat error = %v", name, err)
+		}
+	}
+}
+
+func TestExtractor_ExtractAndHash(t *testing.T) {
+	t.Parallel()
+
+	archivePath := createTestArchive(t, []testEntry{
+		{name: "go/", typeflag: tar.TypeDir, content: ""},
+		{name: "go/VERSION", typeflag: tar.TypeReg, content: "go1.21.0"},
+	})
+
+	content, err := os.ReadFile(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := sha256.Sum256(content)
+
+	digest, err := NewExtractor().ExtractAndHash(archivePath, t.TempDir())
+	if err != nil {
+		t.Fatalf("ExtractAndHash() error = %v", err)
+	}
+
+	if digest != hex.EncodeToString(want[:]) {
+		t.Errorf("ExtractAndHash() = %s, want %s", digest, hex.EncodeToString(want[:]))
+	}
+}
+
+func TestExtract_PAXLongNames(t *testing.T) {
+	t.Parallel()
+
+	// Names longer than 100 bytes are written with a PAX "path" record.
+	longDir := "go/" + strings.Repeat("d", 120)
+
+	t.Run("long path extracts", func(t *testing.T) {
+		t.Parallel()
+
+		archivePath := createTestArchive(t, []testEntry{
+			{name: longDir + "/file", typeflag: tar.TypeReg, content: "content"},
+		})
+		destDir := t.TempDir()
+
+		err := Extract(archivePath, destDir)
+		if err != nil {
+			t.Fatalf("Extract() error = %v", err)
+		}
+
+		_, err = os.Stat(filepath.Join(destDir, longDir, "file"))
+		if err != nil {
+			t.Errorf("long path entry was not extracted: %v", err)
+		}
+	})
+
+	t.Run("long path traversal rejected", func(t *testing.T) {
+		t.Parallel()
+
+		archivePath := createTestArchive(t, []testEntry{
+			{name: longDir + "/../../../escape", typeflag: tar.TypeReg, content: "content"},
+		})
+
+		err := Extract(archivePath, t.TempDir())
+
+		var securityErr *SecurityError
+		if !errors.As(err, &securityErr) {
+			t.Errorf("Extract() error = %v, want SecurityError", err)
+		}
+	})
+}
+
+func TestEntryName(t *testing.T) {
+	t.Parallel()
+
+	header := &tar.Header{Name: "go/short", PAXRecords: map[string]string{"path": "go/../escape"}}
+	if got := entryName(header); got != "go/../escape" {
+		t.Errorf("entryName() = %q, want the PAX path", got)
+	}
+
+	_, err := entryTargetPath(header, t.TempDir())
+
+	var securityErr *SecurityError
+	if !errors.As(err, &securityErr) {
+		t.Errorf("entryTargetPath() error = %v, want SecurityError for PAX traversal", err)
+	}
+}
+
+func TestIsExcluded(t *testing.T) {
+	t.Parallel()
+
+	excludes := PrebuiltPackageExcludes()
+
+	tests := []struct {
+		name       string
+		headerName string
+		expected   bool
+	}{
+		{name: "prebuilt package directory", headerName: "go/pkg/linux_amd64/", expected: true},
+		{name: "prebuilt package file", headerName: "go/pkg/linux_amd64_race/fmt.a", expected: true},
+		{name: "tool directory", headerName: "go/pkg/tool/linux_amd64/link", expected: false},
+		{name: "binary", headerName: "go/bin/go", expected: false},
+		{name: "source file", headerName: "go/src/fmt/print.go", expected: false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := isExcluded(testCase.headerName, excludes)
+			if result != testCase.expected {
+				t.Errorf("isExcluded(%q) = %v, want %v", testCase.headerName, result, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestValidateHeaderName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		headerName       string
+		allowInvalidUTF8 bool
+		wantErr          error
+	}{
+		{name: "valid name", headerName: "go/src/fmt/print.go", allowInvalidUTF8: false, wantErr: nil},
+		{name: "valid unicode name", headerName: "go/test/fixedbugs/ünïcode.go", allowInvalidUTF8: false, wantErr: nil},
+		{name: "absolute path", headerName: "/etc/passwd", allowInvalidUTF8: false, wantErr: errInvalidPath},
+		{name: "parent reference", headerName: "go/../../etc/passwd", allowInvalidUTF8: false, wantErr: errInvalidPath},
+		{name: "null byte", headerName: "go/bin/go\x00.txt", allowInvalidUTF8: false, wantErr: errInvalidCharacters},
+		{name: "newline", headerName: "go/bin/go\nevil", allowInvalidUTF8: false, wantErr: errInvalidCharacters},
+		{name: "tab", headerName: "go/bin/go\tevil", allowInvalidUTF8: false, wantErr: errInvalidCharacters},
+		{name: "escape character", headerName: "go/\x1b[31mred", allowInvalidUTF8: false, wantErr: errInvalidCharacters},
+		{name: "delete character", headerName: "go/bin/\x7fgo", allowInvalidUTF8: false, wantErr: errInvalidCharacters},
+		{name: "invalid utf-8", headerName: "go/bin/\xff\xfe", allowInvalidUTF8: false, wantErr: errInvalidCharacters},
+		{name: "invalid utf-8 allowed", headerName: "go/bin/\xff\xfe", allowInvalidUTF8: true, wantErr: nil},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateHeaderName(testCase.headerName, testCase.allowInvalidUTF8)
+			if testCase.wantErr == nil {
+				if err != nil {
+					t.Errorf("validateHeaderName(%q) unexpected error: %v", testCase.headerName, err)
+				}
+
+				return
+			}
+
+			var securityErr *SecurityError
+			if !errors.As(err, &securityErr) {
+				t.Fatalf("validateHeaderName(%q) error = %v, want SecurityError", testCase.headerName, err)
+			}
+
+			if !errors.Is(err, testCase.wantErr) {
+				t.Errorf("validateHeaderName(%q) error = %v, want %v", testCase.headerName, err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateLinkname(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		typeflag  byte
+		entry     string
+		linkname  string
+		destDir   string
+		sensitive []string
+		wantErr   error
+	}{
+		{
+			name: "relative symlink", typeflag: tar.TypeSymlink, entry: "go/bin/gofmt", linkname: "../pkg/gofmt",
+			destDir: "/usr/local", sensitive: nil, wantErr: nil,
+		},
+		{
+			name: "symlink escaping destination", typeflag: tar.TypeSymlink, entry: "go/bin/evil", linkname: "../../../etc",
+			destDir: "/usr/local", sensitive: nil, wantErr: errInvalidPath,
+		},
+		{
+			name: "absolute symlink", typeflag: tar.TypeSymlink, entry: "go/bin/evil", linkname: "/etc/passwd",
+			destDir: "/usr/local", sensitive: nil, wantErr: errInvalidPath,
+		},
+		{
+			name: "hard link within archive", typeflag: tar.TypeLink, entry: "go/bin/go2", linkname: "go/bin/go",
+			destDir: "/usr/local", sensitive: nil, wantErr: nil,
+		},
+		{
+			name: "hard link escaping destination", typeflag: tar.TypeLink, entry: "go/bin/evil", linkname: "../etc/shadow",
+			destDir: "/usr/local", sensitive: nil, wantErr: errInvalidPath,
+		},
+		{
+			name: "default sensitive paths allow /usr/local", typeflag: tar.TypeSymlink, entry: "go/bin/gofmt",
+			linkname: "../pkg/gofmt", destDir: "/usr/local", sensitive: DefaultSensitivePaths(), wantErr: nil,
+		},
+		{
+			name: "sensitive destination", typeflag: tar.TypeSymlink, entry: "go/bin/gofmt", linkname: "../pkg/gofmt",
+			destDir: "/etc/tools", sensitive: []string{"/etc"}, wantErr: errSensitiveLinkTarget,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			header := &tar.Header{Name: testCase.entry, Typeflag: testCase.typeflag, Linkname: testCase.linkname}
+
+			err := validateLinkname(header, filepath.FromSlash(testCase.destDir), testCase.sensitive)
+			if !errors.Is(err, testCase.wantErr) {
+				t.Errorf("validateLinkname() error = %v, want %v", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestExtract_SymlinkChainEscape(t *testing.T) {
+	t.Parallel()
+
+	// Each link stays within destDir on its own, but on disk go/l2 resolves to the parent of destDir.
+	archivePath := createTestArchive(t, []testEntry{
+		{name: "go/", typeflag: tar.TypeDir, content: ""},
+		{name: "go/l", typeflag: tar.TypeSymlink, content: ".."},
+		{name: "go/l2", typeflag: tar.TypeSymlink, content: "l/.."},
+		{name: "go/l2/pwned", typeflag: tar.TypeReg, content: "pwned"},
+	})
+
+	parent := t.TempDir()
+	destDir := filepath.Join(parent, "dest")
+
+	err := Extract(archivePath, destDir)
+
+	var securityErr *SecurityError
+	if !errors.As(err, &securityErr) || !errors.Is(err, errInvalidPath) {
+		t.Errorf("Extract() error = %v, want a SecurityError for the path through a symlink", err)
+	}
+
+	_, err = os.Lstat(filepath.Join(parent, "pwned"))
+	if !os.IsNotExist(err) {
+		t.Errorf("entry was written outside the destination: %v", err)
+	}
+}
+
+func TestExtract_FileOntoSymlink(t *testing.T) {
+	t.Parallel()
+
+	// go/l3 passes the string check as go/x, but on disk it points at x next to destDir.
+	archivePath := createTestArchive(t, []testEntry{
+		{name: "go/", typeflag: tar.TypeDir, content: ""},
+		{name: "go/l", typeflag: tar.TypeSymlink, content: ".."},
+		{name: "go/l3", typeflag: tar.TypeSymlink, content: "l/../x"},
+		{name: "go/l3", typeflag: tar.TypeReg, content: "pwned"},
+	})
+
+	parent := t.TempDir()
+
+	err := Extract(archivePath, filepath.Join(parent, "dest"))
+	if !errors.Is(err, errInvalidPath) {
+		t.Errorf("Extract() error = %v, want %v for a file written onto a symlink", err, errInvalidPath)
+	}
+
+	_, err = os.Lstat(filepath.Join(parent, "x"))
+	if !os.IsNotExist(err) {
+		t.Errorf("entry was written outside the destination: %v", err)
+	}
+}
+
+func TestExtract_HardLinkThroughSymlink(t *testing.T) {
+	t.Parallel()
+
+	archivePath := createTestArchive(t, []testEntry{
+		{name: "go/", typeflag: tar.TypeDir, content: ""},
+		{name: "go/l", typeflag: tar.TypeSymlink, content: ".."},
+		{name: "go/h", typeflag: tar.TypeLink, content: "go/l/secret"},
+	})
+
+	destDir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(destDir, "secret"), []byte("secret"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Extract(archivePath, destDir)
+	if !errors.Is(err, errInvalidPath) {
+		t.Errorf("Extract() error = %v, want %v for a hard link through a symlink", err, errInvalidPath)
+	}
+}
+
+func TestEntryWriterMkdirAll(t *testing.T) {
+	t.Parallel()
+
+	destDir := t.TempDir()
+	dir := filepath.Join(destDir, "go", "src", "fmt")
+	writer := &entryWriter{buffer: nil, atomic: false, dirs: make(map[string]bool)}
+
+	err := writer.mkdirAll(dir)
+	if err != nil {
+		t.Fatalf("mkdirAll() error = %v", err)
+	}
+
+	for _, created := range []string{dir, filepath.Dir(dir), destDir} {
+		if !writer.dirs[created] {
+			t.Errorf("mkdirAll() did not record %s", created)
+		}
+	}
+
+	// A recorded directory is not created again, even if it has since been removed.
+	err = os.Remove(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = writer.mkdirAll(dir)
+	if err != nil {
+		t.Fatalf("second mkdirAll() error = %v", err)
+	}
+
+	_, err = os.Stat(dir)
+	if !os.IsNotExist(err) {
+		t.Errorf("mkdirAll() recreated a recorded directory: %v", err)
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "go")
+
+	err := os.WriteFile(path, []byte("old"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errRead := errors.New("read failed")
+
+	err = WriteFileAtomic(path, iotest.ErrReader(errRead), 0755)
+	if !errors.Is(err, errRead) {
+		t.Fatalf("WriteFileAtomic() error = %v, want %v", err, errRead)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil || string(content) != "old" {
+		t.Errorf("after failed write, content = %q (err %v), want %q", content, err, "old")
+	}
+
+	err = WriteFileAtomic(path, strings.NewReader("new"), 0700)
+	if err != nil {
+		t.Fatalf("WriteFileAtomic() unexpected error: %v", err)
+	}
+
+	content, err = os.ReadFile(path)
+	if err != nil || string(content) != "new" {
+		t.Errorf("content = %q (err %v), want %q", content, err, "new")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Mode().Perm() != 0700 {
+		t.Errorf("mode = %v, want %v", info.Mode().Perm(), os.FileMode(0700))
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Errorf("expected only the target file to remain, found %d entries", len(entries))
+	}
+}
+
+func TestExtractor_AtomicWrites(t *testing.T) {
+	t.Parallel()
+
+	archivePath := createTestArchive(t, []testEntry{
+		{name: "go/", typeflag: tar.TypeDir, content: ""},
+		{name: "go/bin/go", typeflag: tar.TypeReg, content: "new binary"},
+	})
+
+	destDir := t.TempDir()
+	binPath := filepath.Join(destDir, "go", "bin", "go")
+
+	err := os.MkdirAll(filepath.Dir(binPath), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(binPath, []byte("old binary"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = NewExtractor(WithAtomicWrites(true)).Extract(archivePath, destDir)
+	if err != nil {
+		t.Fatalf("Extract() unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(binPath)
+	if err != nil || string(content) != "new binary" {
+		t.Errorf("content = %q (err %v), want %q", content, err, "new binary")
+	}
+}
+
+func TestExtractor_DurableWrites(t *testing.T) {
+	t.Parallel()
+
+	for name, atomic := range map[string]bool{"in place": false, "atomic": true} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			archivePath := createTestArchive(t, []testEntry{
+				{name: "go/bin/go", typeflag: tar.TypeReg, content: "binary"},
+				{name: "go/VERSION", typeflag: tar.TypeReg, content: "go1.25.5"},
+			})
+			destDir := t.TempDir()
+
+			err := NewExtractor(WithDurableWrites(true), WithAtomicWrites(atomic)).Extract(archivePath, destDir)
+			if err != nil {
+				t.Fatalf("Extract() unexpected error: %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(destDir, "go", "bin", "go"))
+			if err != nil || string(content) != "binary" {
+				t.Errorf("content = %q (err %v), want %q", content, err, "binary")
+			}
+		})
+	}
+}
+
+func TestExtractor_CanonicalModes(t *testing.T) {
+	t.Parallel()
+
+	archivePath := createTestArchive(t, []testEntry{
+		{name: "go/bin/go", typeflag: tar.TypeReg, content: "binary"},
+		{name: "go/README.md", typeflag: tar.TypeReg, content: "readme"},
+	})
+
+	// createTestArchive records mode 0755 for every entry; rewrite README.md as group-writable.
+	rewriteMode(t, archivePath, "go/README.md", 0664)
+
+	destDir := t.TempDir()
+
+	err := NewExtractor(WithCanonicalModes(true)).Extract(archivePath, destDir)
+	if err != nil {
+		t.Fatalf("Extract() unexpected error: %v", err)
+	}
+
+	for name, want := range map[string]os.FileMode{"go": 0755, "go/bin": 0755, "go/bin/go": 0755, "go/README.md": 0644} {
+		info, err := os.Stat(filepath.Join(destDir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if info.Mode().Perm() != want {
+			t.Errorf("mode of %s = %v, want %v", name, info.Mode().Perm(), want)
+		}
+	}
+}
+
+func TestExtractor_StripComponents(t *testing.T) {
+	t.Parallel()
+
+	archivePath := createTestArchive(t, []testEntry{
+		{name: "go/", typeflag: tar.TypeDir, content: ""},
+		{name: "go/bin/", typeflag: tar.TypeDir, content: ""},
+		{name: "go/bin/go", typeflag: tar.TypeReg, content: "go binary"},
+		{name: "go/VERSION", typeflag: tar.TypeReg, content: "go1.21.0"},
+	})
+
+	destDir := filepath.Join(t.TempDir(), "go1.21")
+
+	err := NewExtractor(WithStripComponents(1)).Extract(archivePath, destDir)
+	if err != nil {
+		t.Fatalf("Extract() unexpected error: %v", err)
+	}
+
+	for name, want := range map[string]string{"bin/go": "go binary", "VERSION": "go1.21.0"} {
+		content, err := os.ReadFile(filepath.Join(destDir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatalf("expected %s directly in the destination: %v", name, err)
+		}
+
+		if string(content) != want {
+			t.Errorf("%s = %q, want %q", name, content, want)
+		}
+	}
+
+	_, err = os.Stat(filepath.Join(destDir, "go"))
+	if !os.IsNotExist(err) {
+		t.Errorf("expected no go directory in the destination, got err = %v", err)
+	}
+}
+
+func TestStripComponents(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		header       *tar.Header
+		n            int
+		wantName     string
+		wantLinkname string
+		wantKeep     bool
+		wantErr      error
+	}{
+		{
+			name:         "file",
+			header:       &tar.Header{Name: "go/bin/go", Typeflag: tar.TypeReg},
+			n:            1,
+			wantName:     "bin/go",
+			wantLinkname: "",
+			wantKeep:     true,
+			wantErr:      nil,
+		},
+		{
+			name:         "top-level directory",
+			header:       &tar.Header{Name: "go/", Typeflag: tar.TypeDir},
+			n:            1,
+			wantName:     "",
+			wantLinkname: "",
+			wantKeep:     false,
+			wantErr:      nil,
+		},
+		{
+			name:         "PAX path",
+			header:       &tar.Header{Name: "go/short", PAXRecords: map[string]string{"path": "go/src/long"}},
+			n:            1,
+			wantName:     "src/long",
+			wantLinkname: "",
+			wantKeep:     true,
+			wantErr:      nil,
+		},
+		{
+			name:         "symlink target unchanged",
+			header:       &tar.Header{Name: "go/bin/gofmt", Typeflag: tar.TypeSymlink, Linkname: "../pkg/gofmt"},
+			n:            1,
+			wantName:     "bin/gofmt",
+			wantLinkname: "../pkg/gofmt",
+			wantKeep:     true,
+			wantErr:      nil,
+		},
+		{
+			name:         "hard link target stripped",
+			header:       &tar.Header{Name: "go/bin/gofmt", Typeflag: tar.TypeLink, Linkname: "go/pkg/gofmt"},
+			n:            1,
+			wantName:     "bin/gofmt",
+			wantLinkname: "pkg/gofmt",
+			wantKeep:     true,
+			wantErr:      nil,
+		},
+		{
+			name:         "hard link to a stripped entry",
+			header:       &tar.Header{Name: "go/bin/gofmt", Typeflag: tar.TypeLink, Linkname: "go"},
+			n:            1,
+			wantName:     "",
+			wantLinkname: "",
+			wantKeep:     false,
+			wantErr:      errInvalidPath,
+		},
+		{
+			name:         "traversal rejected before stripping",
+			header:       &tar.Header{Name: "../go/bin/go", Typeflag: tar.TypeReg},
+			n:            1,
+			wantName:     "",
+			wantLinkname: "",
+			wantKeep:     false,
+			wantErr:      errInvalidPath,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			header, keep, err := stripComponents(testCase.header, testCase.n)
+			if !errors.Is(err, testCase.wantErr) || (testCase.wantErr == nil && err != nil) {
+				t.Fatalf("stripComponents() error = %v, want %v", err, testCase.wantErr)
+			}
+
+			if keep != testCase.wantKeep {
+				t.Fatalf("stripComponents() keep = %t, want %t", keep, testCase.wantKeep)
+			}
+
+			if !keep {
+				return
+			}
+
+			if got := entryName(header); got != testCase.wantName {
+				t.Errorf("stripped name = %q, want %q", got, testCase.wantName)
+			}
+
+			if header.Linkname != testCase.wantLinkname {
+				t.Errorf("stripped link name = %q, want %q", header.Linkname, testCase.wantLinkname)
+			}
+		})
+	}
+}
+
+// rewriteMode rewrites the tar.gz archive at archivePath, setting the mode of the named entry.
+func rewriteMode(t *testing.T, archivePath, name string, mode int64) {
+	t.Helper()
+
+	source, err := os.Open(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = source.Close() }()
+
+	gzipReader, err := gzip.NewReader(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	tarReader := tar.NewReader(gzipReader)
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if header.Name == name {
+			header.Mode = mode
+		}
+
+		err = tarWriter.WriteHeader(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = io.Copy(tarWriter, tarReader)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, closer := range []interface{ Close() error }{tarWriter, gzipWriter} {
+		err = closer.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = os.WriteFile(archivePath, buf.Bytes(), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func BenchmarkExtractVersion(b *testing.B) {
 	testCases := []string{
 		"go1.21.0.linux-amd64.tar.gz",
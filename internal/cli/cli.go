@@ -3,10 +3,16 @@
 
 // Package cli provides shared CLI display utilities for goUpdater.
 // It includes functions for formatting output in a consistent tree-like structure,
-// following modern CLI patterns similar to kubectl/docker.
+// following modern CLI patterns similar to kubectl/docker, for printing results and errors as JSON, for confirming
+// actions interactively, and for stopping an operation cleanly on Ctrl-C.
 package cli
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"strings"
 )
 
@@ -69,3 +75,64 @@ func TreeFormat(title string, items []string) string {
 
 	return builder.String()
 }
+
+// PrintJSON writes value to writer as indented JSON followed by a newline.
+// It is used by commands to emit their results when --json is set.
+func PrintJSON(writer io.Writer, value any) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	return nil
+}
+
+// errorOutput is the JSON object PrintJSONError writes.
+type errorOutput struct {
+	Error errorDetail `json:"error"`
+}
+
+// errorDetail holds a failure's message and, when an error in its chain encodes itself as JSON, that encoding.
+type errorDetail struct {
+	Message string         `json:"message"`
+	Detail  json.Marshaler `json:"detail,omitempty"`
+}
+
+// PrintJSONError writes err to writer as a JSON object {"error": {"message": ..., "detail": ...}}, so that a
+// failed command run with --json still prints machine-parseable output. Detail is the first error in err's chain
+// that implements json.Marshaler, such as archive.ExtractionError, and is omitted when there is none.
+func PrintJSONError(writer io.Writer, err error) error {
+	output := errorOutput{Error: errorDetail{Message: err.Error(), Detail: nil}}
+
+	var detail json.Marshaler
+	if errors.As(err, &detail) {
+		output.Error.Detail = detail
+	}
+
+	return PrintJSON(writer, output)
+}
+
+// Confirm writes prompt followed by " [y/N]: " to out and reads the answer from in.
+// It returns true only for "y" or "yes", ignoring case and surrounding whitespace.
+// Any other answer, including an empty line or end of input, declines.
+func Confirm(in io.Reader, out io.Writer, prompt string) (bool, error) {
+	_, err := fmt.Fprintf(out, "%s [y/N]: ", prompt)
+	if err != nil {
+		return false, fmt.Errorf("failed to write prompt: %w", err)
+	}
+
+	response, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(response)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
@@ -4,6 +4,10 @@
 package cli
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -216,3 +220,100 @@ func TestTreeFormatFiltering(t *testing.T) {
 		}
 	}
 }
+
+func TestConfirm(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "yes", input: "yes\n", want: true},
+		{name: "short yes with spaces", input: "  Y \n", want: true},
+		{name: "no", input: "n\n", want: false},
+		{name: "empty line declines", input: "\n", want: false},
+		{name: "end of input declines", input: "", want: false},
+		{name: "answer without newline", input: "y", want: true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+
+			got, err := Confirm(strings.NewReader(testCase.input), &out, "Proceed?")
+			if err != nil {
+				t.Fatalf("Confirm() unexpected error: %v", err)
+			}
+
+			if got != testCase.want {
+				t.Errorf("Confirm(%q) = %v, want %v", testCase.input, got, testCase.want)
+			}
+
+			if out.String() != "Proceed? [y/N]: " {
+				t.Errorf("prompt = %q, want %q", out.String(), "Proceed? [y/N]: ")
+			}
+		})
+	}
+}
+
+// jsonDetailError is an error that encodes itself as JSON, standing in for archive.ExtractionError.
+type jsonDetailError struct{}
+
+func (jsonDetailError) Error() string { return "detailed failure" }
+
+func (jsonDetailError) MarshalJSON() ([]byte, error) { return []byte(`{"member":"go/bin/go"}`), nil }
+
+func TestPrintJSONError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantDetail string
+	}{
+		{name: "plain error", err: errors.New("plain failure"), wantDetail: ""},
+		{
+			name:       "wrapped marshaler",
+			err:        fmt.Errorf("failed to extract archive: %w", jsonDetailError{}),
+			wantDetail: "go/bin/go",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+
+			err := PrintJSONError(&out, testCase.err)
+			if err != nil {
+				t.Fatalf("PrintJSONError() error = %v", err)
+			}
+
+			var decoded struct {
+				Error struct {
+					Message string `json:"message"`
+					Detail  struct {
+						Member string `json:"member"`
+					} `json:"detail"`
+				} `json:"error"`
+			}
+
+			err = json.Unmarshal(out.Bytes(), &decoded)
+			if err != nil {
+				t.Fatalf("PrintJSONError() wrote invalid JSON %q: %v", out.String(), err)
+			}
+
+			if decoded.Error.Message != testCase.err.Error() {
+				t.Errorf("message = %q, want %q", decoded.Error.Message, testCase.err.Error())
+			}
+
+			if decoded.Error.Detail.Member != testCase.wantDetail {
+				t.Errorf("detail member = %q, want %q", decoded.Error.Detail.Member, testCase.wantDetail)
+			}
+		})
+	}
+}
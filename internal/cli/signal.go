@@ -0,0 +1,43 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/nicholas-fedor/goUpdater/internal/logger"
+)
+
+// ErrInterrupted is the cause of a context cancelled by InterruptContext on SIGINT or SIGTERM.
+var ErrInterrupted = errors.New("interrupted")
+
+// InterruptContext returns a context derived from parent that is cancelled, with a cause wrapping ErrInterrupted,
+// when goUpdater receives SIGINT (Ctrl-C) or SIGTERM. This lets a running operation stop and restore the
+// previous state instead of being killed part-way through. Only the first signal is handled: a second one
+// terminates goUpdater immediately. The returned cancel function must be called to stop handling signals.
+func InterruptContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case received := <-signals:
+			logger.Warnf("Received %s; stopping and restoring the previous state (repeat to exit immediately)",
+				received)
+			cancel(fmt.Errorf("%w by %s", ErrInterrupted, received))
+		case <-ctx.Done():
+		}
+
+		signal.Stop(signals)
+	}()
+
+	return ctx, func() { cancel(nil) }
+}
@@ -0,0 +1,58 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// TestInterruptContext is not parallel because it sends SIGINT to the test process.
+func TestInterruptContext(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("os.Process.Signal cannot send os.Interrupt on Windows")
+	}
+
+	ctx, cancel := InterruptContext(context.Background())
+	defer cancel()
+
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = process.Signal(os.Interrupt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("context was not cancelled by SIGINT")
+	}
+
+	if !errors.Is(context.Cause(ctx), ErrInterrupted) {
+		t.Errorf("context.Cause() = %v, want %v", context.Cause(ctx), ErrInterrupted)
+	}
+}
+
+func TestInterruptContextCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := InterruptContext(context.Background())
+	cancel()
+
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("ctx.Err() = %v, want %v", ctx.Err(), context.Canceled)
+	}
+
+	if errors.Is(context.Cause(ctx), ErrInterrupted) {
+		t.Errorf("context.Cause() = %v, want a cancellation without an interrupt", context.Cause(ctx))
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package config loads persistent defaults for goUpdater's command-line flags.
+// The config file is YAML and lives at ~/.config/goUpdater/config.yaml unless --config names another file.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	"github.com/nicholas-fedor/goUpdater/internal/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the config file's path relative to the user's home directory.
+const FileName = ".config/goUpdater/config.yaml"
+
+// Config holds the defaults read from a config file.
+// Empty fields leave the corresponding flag's built-in default in place.
+type Config struct {
+	InstallDir string `yaml:"install-dir"`
+	Channel    string `yaml:"channel"`
+	BaseURL    string `yaml:"base-url"`
+	Workers    int    `yaml:"workers"`
+}
+
+// errNegativeWorkers indicates a config file with a negative worker count.
+var errNegativeWorkers = errors.New("workers must not be negative")
+
+// DefaultPath returns the path of the invoking user's config file.
+// When goUpdater has been re-executed as root through sudo or doas, the home directory of the user
+// who ran it is used, so that elevation does not switch to root's config file.
+func DefaultPath() (string, error) {
+	home, err := homeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, filepath.FromSlash(FileName)), nil
+}
+
+// homeDir returns the home directory of the user who invoked goUpdater.
+func homeDir() (string, error) {
+	if os.Geteuid() == 0 {
+		for _, name := range []string{os.Getenv("SUDO_USER"), os.Getenv("DOAS_USER")} {
+			if name == "" || name == "root" {
+				continue
+			}
+
+			invoker, err := user.Lookup(name)
+			if err == nil {
+				return invoker.HomeDir, nil
+			}
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine the home directory: %w", err)
+	}
+
+	return home, nil
+}
+
+// Load reads the config file at path.
+// Unknown keys are rejected so that a misspelled setting is not silently ignored, and an empty file
+// yields an empty Config. A missing file returns an error wrapping fs.ErrNotExist.
+//
+// When running as root, install-dir and base-url are ignored unless the file is owned by root and not
+// writable by group or others. Under sudo or doas the file normally belongs to the invoking user, who
+// must not be able to choose which directory root replaces or where root downloads Go from.
+func Load(path string) (*Config, error) {
+	return load(path, os.Geteuid())
+}
+
+// load implements Load for a process running with the effective user ID euid.
+func load(path string, euid int) (*Config, error) {
+	file, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config file: %w", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	var cfg Config
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+
+	err = decoder.Decode(&cfg)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	if cfg.Workers < 0 {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, errNegativeWorkers)
+	}
+
+	if euid == 0 && (cfg.InstallDir != "" || cfg.BaseURL != "") {
+		info, err := file.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat config file %s: %w", path, err)
+		}
+
+		if !rootOnly(info) {
+			logger.Warnf("Ignoring install-dir and base-url in %s: running as root and the file is not root-owned", path)
+
+			cfg.InstallDir, cfg.BaseURL = "", ""
+		}
+	}
+
+	return &cfg, nil
+}
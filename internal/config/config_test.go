@@ -0,0 +1,84 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		expected Config
+		wantErr  bool
+	}{
+		{
+			name:    "all settings",
+			content: "install-dir: /opt/go\nchannel: rc\nbase-url: https://mirror.example.com/dl/\nworkers: 4\n",
+			expected: Config{
+				InstallDir: "/opt/go",
+				Channel:    "rc",
+				BaseURL:    "https://mirror.example.com/dl/",
+				Workers:    4,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "empty file",
+			content:  "",
+			expected: Config{InstallDir: "", Channel: "", BaseURL: "", Workers: 0},
+			wantErr:  false,
+		},
+		{
+			name:     "unknown key",
+			content:  "install_dir: /opt/go\n",
+			expected: Config{InstallDir: "", Channel: "", BaseURL: "", Workers: 0},
+			wantErr:  true,
+		},
+		{
+			name:     "negative workers",
+			content:  "workers: -1\n",
+			expected: Config{InstallDir: "", Channel: "", BaseURL: "", Workers: 0},
+			wantErr:  true,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+
+			err := os.WriteFile(path, []byte(testCase.content), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := Load(path)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %t", err, testCase.wantErr)
+			}
+
+			if err == nil && *cfg != testCase.expected {
+				t.Errorf("Load() = %+v, want %+v", *cfg, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := Load(filepath.Join(t.TempDir(), "config.yaml"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Load() error = %v, want %v", err, fs.ErrNotExist)
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+//go:build !windows
+
+package config
+
+import (
+	"os"
+	"syscall"
+)
+
+// writableByOthers is the group and world write permission bits.
+const writableByOthers = 0022
+
+// rootOnly reports whether the file is owned by root and cannot be written by group or others.
+func rootOnly(info os.FileInfo) bool {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+
+	return ok && stat.Uid == 0 && info.Mode().Perm()&writableByOthers == 0
+}
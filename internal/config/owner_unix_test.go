@@ -0,0 +1,110 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+//go:build !windows
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadAsRootIgnoresUntrustedSettings(t *testing.T) {
+	t.Parallel()
+
+	content := "install-dir: /opt/go\nchannel: rc\nbase-url: https://mirror.example.com/dl/\nworkers: 4\n"
+
+	tests := []struct {
+		name     string
+		euid     int
+		expected Config
+	}{
+		{
+			name:     "running as root",
+			euid:     0,
+			expected: Config{InstallDir: "", Channel: "rc", BaseURL: "", Workers: 4},
+		},
+		{
+			name:     "running as the file's owner",
+			euid:     1000,
+			expected: Config{InstallDir: "/opt/go", Channel: "rc", BaseURL: "https://mirror.example.com/dl/", Workers: 4},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+
+			err := os.WriteFile(path, []byte(content), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Unprivileged runs already own the file as a non-root user; as root, hand it to nobody,
+			// as sudo leaves the file with the invoking user.
+			if os.Geteuid() == 0 {
+				err = os.Chown(path, 65534, 65534)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg, err := load(path, testCase.euid)
+			if err != nil {
+				t.Fatalf("load() error = %v", err)
+			}
+
+			if *cfg != testCase.expected {
+				t.Errorf("load() = %+v, want %+v", *cfg, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestRootOnly(t *testing.T) {
+	t.Parallel()
+
+	if os.Geteuid() != 0 {
+		t.Skip("creating root-owned files requires root")
+	}
+
+	tests := []struct {
+		name string
+		mode os.FileMode
+		want bool
+	}{
+		{name: "root-owned", mode: 0644, want: true},
+		{name: "group-writable", mode: 0664, want: false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+
+			err := os.WriteFile(path, nil, 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = os.Chmod(path, testCase.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := rootOnly(info); got != testCase.want {
+				t.Errorf("rootOnly() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
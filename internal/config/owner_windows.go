@@ -0,0 +1,13 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+//go:build windows
+
+package config
+
+import "os"
+
+// rootOnly always reports true on Windows, where goUpdater never runs with an effective user ID of 0.
+func rootOnly(os.FileInfo) bool {
+	return true
+}
@@ -0,0 +1,131 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package download
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ChecksumAlgorithm names the hash function a checksum was computed with.
+type ChecksumAlgorithm string
+
+const (
+	// ChecksumSHA256 is SHA-256, which go.dev/dl publishes for every archive. It is the default.
+	ChecksumSHA256 ChecksumAlgorithm = "sha256"
+	// ChecksumSHA512 is SHA-512, used when the release feed or the user supplies one.
+	ChecksumSHA512 ChecksumAlgorithm = "sha512"
+)
+
+// ErrInvalidChecksum indicates a supplied checksum that is not a hex-encoded digest of its algorithm,
+// or a checksum file without an entry for the archive.
+var ErrInvalidChecksum = errors.New("invalid checksum")
+
+// ErrUnknownChecksumAlgorithm indicates a checksum algorithm other than sha256 or sha512.
+var ErrUnknownChecksumAlgorithm = errors.New("unknown checksum algorithm")
+
+// Checksum is an expected hex-encoded digest of a file together with the algorithm that produced it.
+// The zero Algorithm means ChecksumSHA256.
+type Checksum struct {
+	Algorithm ChecksumAlgorithm
+	Value     string
+}
+
+// ParseChecksumAlgorithm returns the algorithm named by name, case-insensitively.
+// An empty name selects ChecksumSHA256.
+func ParseChecksumAlgorithm(name string) (ChecksumAlgorithm, error) {
+	switch algorithm := ChecksumAlgorithm(strings.ToLower(name)); algorithm {
+	case "", ChecksumSHA256:
+		return ChecksumSHA256, nil
+	case ChecksumSHA512:
+		return ChecksumSHA512, nil
+	default:
+		return "", fmt.Errorf("%q (want %s or %s): %w", name, ChecksumSHA256, ChecksumSHA512,
+			ErrUnknownChecksumAlgorithm)
+	}
+}
+
+// String returns the algorithm's name, defaulting to sha256.
+func (a ChecksumAlgorithm) String() string {
+	if a == "" {
+		return string(ChecksumSHA256)
+	}
+
+	return string(a)
+}
+
+// newHash returns a new hash.Hash computing the algorithm.
+func (a ChecksumAlgorithm) newHash() hash.Hash {
+	if a == ChecksumSHA512 {
+		return sha512.New()
+	}
+
+	return sha256.New()
+}
+
+// hexLength returns the length of a hex-encoded digest of the algorithm.
+func (a ChecksumAlgorithm) hexLength() int {
+	return a.newHash().Size() * 2 //nolint:mnd // two hex characters per byte
+}
+
+// fileChecksum returns the strongest checksum the release feed publishes for file.
+func fileChecksum(file *goFileInfo) Checksum {
+	if file.Sha512 != "" {
+		return Checksum{Algorithm: ChecksumSHA512, Value: file.Sha512}
+	}
+
+	return Checksum{Algorithm: ChecksumSHA256, Value: file.Sha256}
+}
+
+// VerifyChecksum checks that the checksum of the file at filePath matches expected, a hex-encoded
+// digest such as the SHA256 one published next to each archive on go.dev/dl. It is meant for archives obtained
+// some other way, e.g. copied to an air-gapped host. A mismatch returns an error wrapping ErrChecksumMismatch.
+func VerifyChecksum(filePath string, expected Checksum) error {
+	algorithm, err := ParseChecksumAlgorithm(string(expected.Algorithm))
+	if err != nil {
+		return err
+	}
+
+	value := strings.TrimSpace(expected.Value)
+
+	_, err = hex.DecodeString(value)
+	if err != nil || len(value) != algorithm.hexLength() {
+		return fmt.Errorf("%q is not a %s checksum of %d hexadecimal characters: %w",
+			expected.Value, algorithm, algorithm.hexLength(), ErrInvalidChecksum)
+	}
+
+	return verifyChecksum(filePath, Checksum{Algorithm: algorithm, Value: value})
+}
+
+// ReadChecksumFile returns the checksum of archiveName from the checksum file at path.
+// The file may hold just the checksum, or lines in the "checksum  name" format of sha256sum and sha512sum,
+// in which case the line for archiveName is used.
+func ReadChecksumFile(path, archiveName string) (string, error) {
+	content, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return "", fmt.Errorf("failed to read checksum file: %w", err)
+	}
+
+	lines := strings.FieldsFunc(string(content), func(r rune) bool { return r == '\n' || r == '\r' })
+
+	for _, line := range lines {
+		fields := strings.Fields(line)
+
+		switch {
+		case len(fields) == 1 && len(lines) == 1:
+			return fields[0], nil
+		case len(fields) == 2 && filepath.Base(strings.TrimPrefix(fields[1], "*")) == archiveName:
+			return fields[0], nil
+		}
+	}
+
+	return "", fmt.Errorf("no checksum for %s in %s: %w", archiveName, path, ErrInvalidChecksum)
+}
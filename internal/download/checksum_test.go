@@ -0,0 +1,185 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestVerifyChecksum_Supplied(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "go1.21.0.linux-amd64.tar.gz")
+	content := []byte("archive content")
+
+	err := os.WriteFile(path, content, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+	sum512 := sha512.Sum512(content)
+	checksum512 := hex.EncodeToString(sum512[:])
+
+	tests := []struct {
+		name      string
+		algorithm ChecksumAlgorithm
+		expected  string
+		wantErr   error
+	}{
+		{name: "match", algorithm: "", expected: checksum, wantErr: nil},
+		{
+			name:      "uppercase with surrounding space",
+			algorithm: ChecksumSHA256,
+			expected:  " " + strings.ToUpper(checksum) + "\n",
+			wantErr:   nil,
+		},
+		{name: "mismatch", algorithm: ChecksumSHA256, expected: strings.Repeat("0", 64), wantErr: ErrChecksumMismatch},
+		{name: "too short", algorithm: ChecksumSHA256, expected: checksum[:40], wantErr: ErrInvalidChecksum},
+		{name: "not hexadecimal", algorithm: "", expected: strings.Repeat("z", 64), wantErr: ErrInvalidChecksum},
+		{name: "sha512 match", algorithm: ChecksumSHA512, expected: checksum512, wantErr: nil},
+		{name: "sha512 mismatch", algorithm: "SHA512", expected: strings.Repeat("0", 128), wantErr: ErrChecksumMismatch},
+		{name: "sha256 for sha512", algorithm: ChecksumSHA512, expected: checksum, wantErr: ErrInvalidChecksum},
+		{name: "unknown algorithm", algorithm: "md5", expected: checksum, wantErr: ErrUnknownChecksumAlgorithm},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := VerifyChecksum(path, Checksum{Algorithm: testCase.algorithm, Value: testCase.expected})
+			if !errors.Is(err, testCase.wantErr) || (testCase.wantErr == nil && err != nil) {
+				t.Errorf("VerifyChecksum() error = %v, want %v", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadChecksumFile(t *testing.T) {
+	t.Parallel()
+
+	checksum := strings.Repeat("a", 64)
+	other := strings.Repeat("b", 64)
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+		wantErr  bool
+	}{
+		{name: "bare checksum", content: checksum + "\n", expected: checksum, wantErr: false},
+		{
+			name:     "sha256sum lines",
+			content:  other + "  go1.21.0.darwin-arm64.tar.gz\r\n" + checksum + "  go1.21.0.linux-amd64.tar.gz\r\n",
+			expected: checksum,
+			wantErr:  false,
+		},
+		{
+			name:     "binary mode with a path",
+			content:  checksum + " *downloads/go1.21.0.linux-amd64.tar.gz\n",
+			expected: checksum,
+			wantErr:  false,
+		},
+		{name: "no entry for the archive", content: other + "  go1.21.0.darwin-arm64.tar.gz\n", expected: "",
+			wantErr: true},
+		{name: "empty file", content: "", expected: "", wantErr: true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "SHA256SUMS")
+
+			err := os.WriteFile(path, []byte(testCase.content), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ReadChecksumFile(path, "go1.21.0.linux-amd64.tar.gz")
+			if testCase.wantErr != errors.Is(err, ErrInvalidChecksum) {
+				t.Fatalf("ReadChecksumFile() error = %v, wantErr %t", err, testCase.wantErr)
+			}
+
+			if got != testCase.expected {
+				t.Errorf("ReadChecksumFile() = %q, want %q", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestVerifyChecksum_NamesAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "archive.tar.gz")
+
+	err := os.WriteFile(path, []byte("archive content"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = VerifyChecksum(path, Checksum{Algorithm: ChecksumSHA512, Value: strings.Repeat("0", 128)})
+	if !errors.Is(err, ErrChecksumMismatch) || !strings.Contains(err.Error(), "sha512 checksum mismatch") {
+		t.Errorf("VerifyChecksum() error = %v, want a sha512 %v", err, ErrChecksumMismatch)
+	}
+}
+
+func TestFileChecksum(t *testing.T) {
+	t.Parallel()
+
+	sha256Only := createGoFileInfo("go.tar.gz", "linux", "amd64", "archive", "go1.21.0", "abc", 1)
+
+	got := fileChecksum(&sha256Only)
+	if got != (Checksum{Algorithm: ChecksumSHA256, Value: "abc"}) {
+		t.Errorf("fileChecksum() = %+v, want the sha256 checksum", got)
+	}
+
+	withSha512 := sha256Only
+	withSha512.Sha512 = "def"
+
+	got = fileChecksum(&withSha512)
+	if got != (Checksum{Algorithm: ChecksumSHA512, Value: "def"}) {
+		t.Errorf("fileChecksum() = %+v, want the sha512 checksum", got)
+	}
+}
+
+func TestGetReleaseReturnsChecksumAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	file := createGoFileInfo("go1.21.0.test-archive.tar.gz", runtime.GOOS, runtime.GOARCH, "archive", "go1.21.0",
+		strings.Repeat("a", 64), 1)
+	file.Sha512 = strings.Repeat("b", 128)
+
+	resolve := func(context.Context) (*GoVersionInfo, error) {
+		return &GoVersionInfo{Version: "go1.21.0", Stable: true, Files: []goFileInfo{file}}, nil
+	}
+
+	var verified Checksum
+
+	fetch := func(_ context.Context, _, _ string, expected Checksum) error {
+		verified = expected
+
+		return nil
+	}
+
+	_, got, err := getRelease(context.Background(), t.TempDir(), resolve, fetch)
+	if err != nil {
+		t.Fatalf("getRelease() error = %v", err)
+	}
+
+	want := Checksum{Algorithm: ChecksumSHA512, Value: file.Sha512}
+	if got != want || verified != want {
+		t.Errorf("getRelease() checksum = %+v, verified against %+v, want %+v", got, verified, want)
+	}
+}
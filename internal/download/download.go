@@ -6,19 +6,26 @@
 package download
 
 import (
+	"cmp"
 	"context"
-	"crypto/sha256"
+	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/user"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/nicholas-fedor/goUpdater/internal/logger"
@@ -28,20 +35,123 @@ import (
 // throttleDuration defines the update interval for the progress bar in milliseconds.
 const throttleDuration = 100 // Progress bar update interval in milliseconds
 
+// maxSignatureSize caps the size of a downloaded detached signature.
+const maxSignatureSize = 64 << 10
+
+// downloadFilePerm is the permission used for partial downloads.
+const downloadFilePerm = 0644
+
+// maxClockSkew is how far the local clock may drift from the server's Date header before a warning is logged.
+const maxClockSkew = 10 * time.Minute
+
+// defaultHTTPTimeout bounds connecting, the TLS handshake, and waiting for response headers.
+const defaultHTTPTimeout = 30 * time.Second
+
+// Default retry policy for transient download failures.
+const (
+	defaultRetries        = 3               // Retries after the first attempt
+	defaultRetryBaseDelay = 1 * time.Second // Delay before the first retry, doubled for each further retry
+)
+
+// Go release feed and download locations.
+const (
+	defaultBaseURL  = "https://go.dev/dl/"     // Default base URL for Go release downloads
+	BaseURLEnv      = "GO_UPDATER_BASE_URL"    // Environment variable overriding the base URL, e.g. for a mirror
+	latestFeedQuery = "?mode=json"             // Feed listing the current releases
+	allFeedQuery    = "?mode=json&include=all" // Feed listing every published release
+)
+
+// File kinds published in the Go release feed.
+const (
+	fileKindArchive   = "archive"   // Binary distribution archive (.tar.gz or .zip)
+	fileKindInstaller = "installer" // Platform installer (.msi or .pkg)
+	fileKindSource    = "source"    // Source tarball
+)
+
+// Pre-release kinds, ordered so that a final release sorts above its release candidates and betas.
+const (
+	releaseBeta  = iota // Beta release, e.g. go1.24beta1
+	releaseRC           // Release candidate, e.g. go1.24rc1
+	releaseFinal        // Final release, e.g. go1.24.0
+)
+
+// maxVersionParts is the most dot-separated numbers in a Go release version, as in go1.24.0.
+const maxVersionParts = 3
+
+// Channel selects the least stable kind of release accepted when resolving the latest version.
+type Channel string
+
+// Release channels. Each channel also accepts the more stable releases, so the newest release
+// wins once a release candidate or beta has been superseded by its final release.
+const (
+	ChannelStable Channel = "stable" // Final releases only
+	ChannelRC     Channel = "rc"     // Final releases and release candidates
+	ChannelBeta   Channel = "beta"   // Final releases, release candidates, and betas
+)
+
+// httpClientMutex protects access to client.
+var httpClientMutex sync.Mutex //nolint:gochecknoglobals
+
+// client is the HTTP client used for the release feed, archives, and signatures.
+// It is created with NewHTTPClient on first use unless replaced by SetHTTPClient.
+var client *http.Client //nolint:gochecknoglobals
+
+// retryMutex protects access to retries and retryBaseDelay.
+var retryMutex sync.Mutex //nolint:gochecknoglobals
+
+// retries is the number of times a transient download failure is retried.
+var retries = defaultRetries //nolint:gochecknoglobals
+
+// retryBaseDelay is the delay before the first retry.
+var retryBaseDelay = defaultRetryBaseDelay //nolint:gochecknoglobals
+
+// versionsCacheMutex protects access to versionsCache.
+var versionsCacheMutex sync.Mutex //nolint:gochecknoglobals
+
+// versionsCache holds the full release index once it has been fetched.
+var versionsCache []GoVersionInfo //nolint:gochecknoglobals
+
 // errUnexpectedStatus indicates an unexpected HTTP status code.
 var errUnexpectedStatus = errors.New("unexpected status")
 
 // errNoStableVersion indicates no stable Go version was found.
 var errNoStableVersion = errors.New("no stable version found")
 
-// errNoArchive indicates no archive was found for the platform.
-var errNoArchive = errors.New("no archive")
+// ErrNoArchiveForPlatform indicates the release has no binary archive for the platform.
+// It is returned even when installer or source files exist, since only archives can be installed.
+var ErrNoArchiveForPlatform = errors.New("no archive for platform")
+
+// ErrVersionNotFound indicates the requested Go version is not published in the release feed.
+var ErrVersionNotFound = errors.New("version not found")
+
+// ErrClockSkew indicates TLS verification failed because a certificate appears expired or not yet valid,
+// which usually means the system clock is wrong.
+var ErrClockSkew = errors.New("certificate validity check failed; check that the system date and time are correct")
+
+// ErrInvalidBaseURL indicates that GO_UPDATER_BASE_URL is not an absolute http or https URL.
+var ErrInvalidBaseURL = errors.New("invalid base URL")
+
+// ErrUnknownChannel indicates a release channel other than stable, rc, or beta.
+var ErrUnknownChannel = errors.New("unknown release channel")
+
+// errServerError indicates a 5xx response, which is treated as transient.
+var errServerError = errors.New("server error")
 
 // errDownloadFailed indicates the download failed.
 var errDownloadFailed = errors.New("download failed")
 
-// errChecksumMismatch indicates a checksum mismatch.
-var errChecksumMismatch = errors.New("checksum mismatch")
+// ErrChecksumMismatch indicates a file's SHA256 does not match the checksum published in the release index.
+// Downloaded archives that fail this check are removed before they can be installed.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// ClientOption configures the HTTP client built by NewHTTPClient.
+type ClientOption func(*clientConfig)
+
+// clientConfig holds the settings applied by ClientOption values.
+type clientConfig struct {
+	timeout time.Duration
+	proxy   *url.URL
+}
 
 // GoVersionInfo represents the structure of a Go version from the official API.
 type GoVersionInfo struct {
@@ -57,23 +167,24 @@ type goFileInfo struct {
 	Arch     string `json:"arch"`
 	Version  string `json:"version"`
 	Sha256   string `json:"sha256"`
+	Sha512   string `json:"sha512,omitempty"`
 	Size     int    `json:"size"`
 	Kind     string `json:"kind"`
 }
 
 // Download downloads the latest Go version and handles display logic.
 // It wraps the existing GetLatest functionality with appropriate logging.
-func Download() (string, string, error) {
+func Download() (string, Checksum, error) {
 	logger.Debug("Starting download operation")
 
 	path, checksum, err := GetLatest("")
 	if err != nil {
 		logger.Errorf("Error downloading Go archive: %v", err)
 
-		return "", "", err
+		return "", Checksum{Algorithm: "", Value: ""}, err
 	}
 
-	logger.Debugf("Download completed: path=%s, checksum=%s", path, checksum)
+	logger.Debugf("Download completed: path=%s, %s checksum=%s", path, checksum.Algorithm, checksum.Value)
 
 	return path, checksum, nil
 }
@@ -85,79 +196,580 @@ func Download() (string, string, error) {
 // It verifies the checksum of any found archives.
 // If a valid archive exists, it skips the download.
 // Otherwise, it downloads the archive to the destination directory and verifies the checksum.
-// It returns the path to the file and its checksum, or an error.
-func GetLatest(destDir string) (string, string, error) {
+// It returns the path to the file and the checksum it was verified against, or an error.
+func GetLatest(destDir string) (string, Checksum, error) {
+	return getLatest(context.Background(), destDir, downloadAndVerify)
+}
+
+// Get downloads the archive for the given Go version for the current platform to destDir,
+// searching for existing archives and verifying the checksum exactly as GetLatest does.
+// An empty version downloads the latest stable release.
+func Get(version, destDir string) (string, Checksum, error) {
+	return GetContext(context.Background(), version, destDir)
+}
+
+// GetContext behaves like Get, but stops fetching the release feed and the archive, including
+// retries, once ctx is done.
+func GetContext(ctx context.Context, version, destDir string) (string, Checksum, error) {
+	if version == "" {
+		return getLatest(ctx, destDir, downloadAndVerify)
+	}
+
+	resolve := func(ctx context.Context) (*GoVersionInfo, error) { return GetVersionInfoContext(ctx, version) }
+
+	return getRelease(ctx, destDir, resolve, downloadAndVerify)
+}
+
+// GetLatestResumable behaves like GetLatest, but keeps interrupted downloads as a ".partial" file
+// in destDir and resumes them with an HTTP Range request on the next call.
+// The completed file is verified against the published checksum before it is returned.
+func GetLatestResumable(destDir string) (string, Checksum, error) {
+	return getLatest(context.Background(), destDir, downloadResumable)
+}
+
+// downloadFunc fetches url to destPath and checks it against expected.
+type downloadFunc func(ctx context.Context, url, destPath string, expected Checksum) error
+
+// getLatest implements GetLatest, fetching the archive with the given download function.
+func getLatest(ctx context.Context, destDir string, download downloadFunc) (string, Checksum, error) {
+	return getRelease(ctx, destDir, getLatestVersion, download)
+}
+
+// getRelease downloads the archive of the release returned by resolve to destDir using the download function.
+func getRelease(
+	ctx context.Context,
+	destDir string,
+	resolve func(ctx context.Context) (*GoVersionInfo, error),
+	download downloadFunc,
+) (string, Checksum, error) {
 	if destDir == "" {
-		destDir = os.TempDir()
+		var err error
+
+		destDir, err = TempDir()
+		if err != nil {
+			return "", Checksum{Algorithm: "", Value: ""}, err
+		}
+
 		logger.Debugf("Using temporary directory: %s", destDir)
 	}
 
-	logger.Debugf("Starting download of latest Go archive to: %s", destDir)
-
-	version, err := getLatestVersion()
+	version, err := resolve(ctx)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to get latest version: %w", err)
+		return "", Checksum{Algorithm: "", Value: ""}, fmt.Errorf("failed to get version info: %w", err)
 	}
 
+	logger.Debugf("Starting download of Go %s archive to: %s", version.Version, destDir)
+
 	file, err := getPlatformFile(version)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to get platform file: %w", err)
+		return "", Checksum{Algorithm: "", Value: ""}, fmt.Errorf("failed to get platform file: %w", err)
 	}
 
 	home, err := os.UserHomeDir()
 	if err != nil {
-		return "", "", fmt.Errorf("failed to get home directory: %w", err)
+		return "", Checksum{Algorithm: "", Value: ""}, fmt.Errorf("failed to get home directory: %w", err)
 	}
 
+	checksum := fileChecksum(file)
+
 	// Check for existing archives in user directories first, then destination directory
 	searchDirs := getSearchDirectories(home, destDir)
 	for _, dir := range searchDirs {
 		candidatePath := filepath.Join(dir, file.Filename)
-		if checkExistingArchive(candidatePath, file.Sha256) {
+		if checkExistingArchive(candidatePath, checksum) {
 			logger.Infof("Valid Go archive already exists at %s", candidatePath)
-			logger.Infof("SHA256 checksum: %s...", file.Sha256[:12])
+			logger.Infof("%s checksum: %s...", strings.ToUpper(checksum.Algorithm.String()), checksum.Value[:12])
 
-			return candidatePath, file.Sha256, nil
+			return candidatePath, checksum, nil
 		}
 	}
 
-	url := "https://go.dev/dl/" + file.Filename
+	base, err := baseURL()
+	if err != nil {
+		return "", Checksum{Algorithm: "", Value: ""}, err
+	}
+
+	url := base + file.Filename
 	destPath := filepath.Join(destDir, file.Filename)
 
-	err = downloadAndVerify(url, destPath, file.Sha256)
+	err = download(ctx, url, destPath, checksum)
 	if err != nil {
-		return "", "", err
+		return "", Checksum{Algorithm: "", Value: ""}, err
 	}
 
 	logger.Infof("Successfully downloaded Go archive to: %s", destPath)
-	logger.Infof("SHA256 checksum: %s...", file.Sha256[:12])
+	logger.Infof("%s checksum: %s...", strings.ToUpper(checksum.Algorithm.String()), checksum.Value[:12])
+
+	return destPath, checksum, nil
+}
 
-	return destPath, file.Sha256, nil
+// WithTimeout sets how long to wait for a connection, the TLS handshake, and the response headers.
+// It does not bound the transfer of a response body, so slow but progressing downloads are not cut off.
+// A non-positive timeout keeps the default of 30 seconds.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(config *clientConfig) {
+		if timeout > 0 {
+			config.timeout = timeout
+		}
+	}
+}
+
+// WithProxy routes all requests through the given proxy.
+// A nil proxy keeps the default of honoring HTTP_PROXY, HTTPS_PROXY, and NO_PROXY.
+func WithProxy(proxy *url.URL) ClientOption {
+	return func(config *clientConfig) {
+		if proxy != nil {
+			config.proxy = proxy
+		}
+	}
+}
+
+// NewHTTPClient creates an HTTP client for talking to the release site.
+// By default it uses the proxy from the environment and a 30 second timeout for establishing
+// connections and receiving response headers.
+func NewHTTPClient(options ...ClientOption) *http.Client {
+	config := clientConfig{timeout: defaultHTTPTimeout, proxy: nil}
+	for _, option := range options {
+		option(&config)
+	}
+
+	transport, _ := http.DefaultTransport.(*http.Transport)
+	transport = transport.Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	if config.proxy != nil {
+		transport.Proxy = http.ProxyURL(config.proxy)
+	}
+
+	transport.DialContext = (&net.Dialer{Timeout: config.timeout}).DialContext
+	transport.TLSHandshakeTimeout = config.timeout
+	transport.ResponseHeaderTimeout = config.timeout
+
+	return &http.Client{Transport: transport, CheckRedirect: nil, Jar: nil, Timeout: 0}
+}
+
+// SetHTTPClient replaces the HTTP client used for the release feed, archives, and signatures.
+// Passing nil restores the default client from NewHTTPClient.
+func SetHTTPClient(httpClient *http.Client) {
+	httpClientMutex.Lock()
+	defer httpClientMutex.Unlock()
+
+	client = httpClient
+}
+
+// httpClient returns the configured HTTP client, creating the default one on first use.
+func httpClient() *http.Client {
+	httpClientMutex.Lock()
+	defer httpClientMutex.Unlock()
+
+	if client == nil {
+		client = NewHTTPClient()
+	}
+
+	return client
+}
+
+// SetRetryPolicy sets how often transient failures fetching the release feed or an archive are retried,
+// and the delay before the first retry. Each further retry doubles the delay, with random jitter.
+// Negative values keep the current setting; zero retries disables retrying.
+func SetRetryPolicy(maxRetries int, baseDelay time.Duration) {
+	retryMutex.Lock()
+	defer retryMutex.Unlock()
+
+	if maxRetries >= 0 {
+		retries = maxRetries
+	}
+
+	if baseDelay >= 0 {
+		retryBaseDelay = baseDelay
+	}
+}
+
+// withRetry runs operation under the configured retry policy.
+func withRetry(ctx context.Context, name string, operation func() error) error {
+	retryMutex.Lock()
+	maxRetries, baseDelay := retries, retryBaseDelay
+	retryMutex.Unlock()
+
+	return retry(ctx, name, maxRetries, baseDelay, operation)
+}
+
+// retry runs operation, retrying up to maxRetries times while it fails with a transient error.
+// Client errors, checksum mismatches, and other permanent failures are returned immediately,
+// and no retry is made, nor waited for, once ctx is done.
+func retry(ctx context.Context, name string, maxRetries int, baseDelay time.Duration, operation func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := operation()
+		if err == nil || attempt >= maxRetries || !isTransient(err) {
+			return err
+		}
+
+		if ctx.Err() != nil {
+			return fmt.Errorf("%w (last error: %w)", ctx.Err(), err)
+		}
+
+		delay := backoff(baseDelay, attempt)
+		logger.Warnf("%s failed: %v; retrying in %s (%d/%d)", name, err, delay.Round(time.Millisecond),
+			attempt+1, maxRetries)
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return fmt.Errorf("%w (last error: %w)", ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the delay before retry number attempt (counting from zero): baseDelay doubled
+// attempt times, scaled by a random factor in [0.5, 1) so that concurrent clients spread out.
+func backoff(baseDelay time.Duration, attempt int) time.Duration {
+	delay := baseDelay << attempt
+
+	return delay/2 + rand.N(delay/2+1) //nolint:gosec // Jitter does not need a cryptographic source.
+}
+
+// isTransient reports whether err is worth retrying: a 5xx response, a connection failure,
+// a timeout, or a response body cut short. A host that DNS reports as nonexistent is not retried.
+func isTransient(err error) bool {
+	if errors.Is(err, errServerError) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) && netErr.Timeout()
 }
 
 // GetLatestVersionInfo fetches the latest stable Go version information from the official API.
 // It returns the version info for the latest stable version or an error if not found.
 func GetLatestVersionInfo() (*GoVersionInfo, error) {
-	return getLatestVersion()
+	return getLatestVersion(context.Background())
+}
+
+// GetLatestVersionInfoContext behaves like GetLatestVersionInfo, but gives up once ctx is done.
+func GetLatestVersionInfoContext(ctx context.Context) (*GoVersionInfo, error) {
+	return getLatestVersion(ctx)
+}
+
+// GetVersionInfo fetches the information for a specific Go version from the release index,
+// which lists every published release. The "go" prefix is optional; see findVersion for matching.
+// It returns an error wrapping ErrVersionNotFound if the version has not been published.
+func GetVersionInfo(version string) (*GoVersionInfo, error) {
+	return GetVersionInfoContext(context.Background(), version)
+}
+
+// GetVersionInfoContext behaves like GetVersionInfo, but gives up once ctx is done.
+func GetVersionInfoContext(ctx context.Context, version string) (*GoVersionInfo, error) {
+	logger.Debugf("Fetching Go version information for %s", version)
+
+	versions, err := cachedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return findVersion(versions, version)
+}
+
+// ListVersions returns every published stable Go release, newest first.
+// Beta releases and release candidates are included when includeUnstable is true.
+// The release index is fetched once and cached for the lifetime of the process.
+func ListVersions(includeUnstable bool) ([]GoVersionInfo, error) {
+	versions, err := cachedVersions(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return filterVersions(versions, includeUnstable), nil
+}
+
+// ParseChannel parses a release channel name as given on the command line.
+// An empty name selects ChannelStable.
+func ParseChannel(name string) (Channel, error) {
+	switch channel := Channel(strings.ToLower(name)); channel {
+	case "", ChannelStable:
+		return ChannelStable, nil
+	case ChannelRC, ChannelBeta:
+		return channel, nil
+	default:
+		return "", fmt.Errorf("%q: %w", name, ErrUnknownChannel)
+	}
+}
+
+// GetLatestForChannel returns the newest published release accepted by channel.
+// Beta releases sort below release candidates, which sort below the final release of the same version.
+func GetLatestForChannel(channel Channel) (*GoVersionInfo, error) {
+	if channel == ChannelStable {
+		return GetLatestVersionInfo()
+	}
+
+	versions, err := cachedVersions(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return latestForChannel(versions, channel)
+}
+
+// latestForChannel returns the newest release in versions accepted by channel.
+func latestForChannel(versions []GoVersionInfo, channel Channel) (*GoVersionInfo, error) {
+	minKind := releaseFinal
+
+	switch channel {
+	case ChannelStable:
+	case ChannelRC:
+		minKind = releaseRC
+	case ChannelBeta:
+		minKind = releaseBeta
+	default:
+		return nil, fmt.Errorf("%q: %w", channel, ErrUnknownChannel)
+	}
+
+	for _, v := range filterVersions(versions, true) {
+		if _, kind, _ := parseRelease(v.Version); kind >= minKind {
+			logger.Debugf("Found %s channel version: %s", channel, v.Version)
+
+			return &v, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no %s release found: %w", channel, ErrVersionNotFound)
+}
+
+// cachedVersions returns the full release index, fetching it on first use.
+// A failed fetch is not cached, so a later call retries.
+func cachedVersions(ctx context.Context) ([]GoVersionInfo, error) {
+	versionsCacheMutex.Lock()
+	defer versionsCacheMutex.Unlock()
+
+	if versionsCache != nil {
+		return versionsCache, nil
+	}
+
+	logger.Debug("Fetching Go release index")
+
+	base, err := baseURL()
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := fetchVersions(ctx, base+allFeedQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	versionsCache = versions
+
+	return versionsCache, nil
+}
+
+// filterVersions returns a copy of versions sorted newest first, keeping unstable releases only when
+// includeUnstable is true.
+func filterVersions(versions []GoVersionInfo, includeUnstable bool) []GoVersionInfo {
+	filtered := make([]GoVersionInfo, 0, len(versions))
+
+	for _, v := range versions {
+		if v.Stable || includeUnstable {
+			filtered = append(filtered, v)
+		}
+	}
+
+	slices.SortStableFunc(filtered, func(a, b GoVersionInfo) int {
+		return CompareGoVersions(b.Version, a.Version)
+	})
+
+	return filtered
+}
+
+// CompareGoVersions compares two Go release versions such as "go1.21.0", "go1.21", "go1.24rc1", or "go1.24beta2",
+// with or without the "go" prefix. Returns -1 if a < b, 0 if a == b, 1 if a > b. Components are compared
+// numerically, so go1.21.0 is newer than go1.9.1. Missing components count as 0, and beta releases sort
+// below release candidates, which sort below the final release of the same version.
+func CompareGoVersions(a, b string) int {
+	numsA, kindA, preA := parseRelease(a)
+	numsB, kindB, preB := parseRelease(b)
+
+	for i := range max(len(numsA), len(numsB)) {
+		var x, y int
+
+		if i < len(numsA) {
+			x = numsA[i]
+		}
+
+		if i < len(numsB) {
+			y = numsB[i]
+		}
+
+		if x != y {
+			return cmp.Compare(x, y)
+		}
+	}
+
+	if kindA != kindB {
+		return cmp.Compare(kindA, kindB)
+	}
+
+	return cmp.Compare(preA, preB)
+}
+
+// NormalizeGoVersion returns version in the canonical goX.Y.Z form, adding the go prefix and a missing patch
+// component, so "1.21" and "go1.21" both become "go1.21.0". Pre-releases such as go1.24rc1 have no patch
+// component and only gain the prefix.
+func NormalizeGoVersion(version string) string {
+	version = "go" + strings.TrimPrefix(version, "go")
+
+	if _, kind, _ := parseRelease(version); kind == releaseFinal && strings.Count(version, ".") == 1 {
+		return version + ".0"
+	}
+
+	return version
+}
+
+// IsGoVersion reports whether version names a Go release, such as "go1.21.0", "1.21", or "go1.24rc1":
+// up to three dot-separated numbers, optionally followed by a beta or rc number. Anything else, including
+// a path separator or "..", is rejected, so a valid version can be used as a directory name.
+func IsGoVersion(version string) bool {
+	version = strings.TrimPrefix(version, "go")
+
+	for _, marker := range []string{"beta", "rc"} {
+		base, pre, found := strings.Cut(version, marker)
+		if found {
+			if !isDigits(pre) {
+				return false
+			}
+
+			version = base
+
+			break
+		}
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) > maxVersionParts {
+		return false
+	}
+
+	for _, part := range parts {
+		if !isDigits(part) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// parseRelease splits a Go release version into its numeric components, pre-release kind, and pre-release number.
+// Unparsable components are treated as 0.
+func parseRelease(version string) ([]int, int, int) {
+	version = strings.TrimPrefix(version, "go")
+	kind, pre := releaseFinal, 0
+
+	for _, suffix := range []struct {
+		marker string
+		kind   int
+	}{{"beta", releaseBeta}, {"rc", releaseRC}} {
+		index := strings.Index(version, suffix.marker)
+		if index < 0 {
+			continue
+		}
+
+		kind = suffix.kind
+		pre, _ = strconv.Atoi(version[index+len(suffix.marker):])
+		version = version[:index]
+
+		break
+	}
+
+	parts := strings.Split(version, ".")
+	nums := make([]int, len(parts))
+
+	for i, part := range parts {
+		nums[i], _ = strconv.Atoi(part)
+	}
+
+	return nums, kind, pre
 }
 
 // getLatestVersion fetches the latest stable Go version information from the official API.
 // It returns the version info for the current platform or an error if not found.
-func getLatestVersion() (*GoVersionInfo, error) {
+func getLatestVersion(ctx context.Context) (*GoVersionInfo, error) {
 	logger.Debug("Fetching latest Go version information from official API")
 
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://go.dev/dl/?mode=json", nil)
+	base, err := baseURL()
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := fetchVersions(ctx, base+latestFeedQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the latest stable version
+	for _, v := range versions {
+		if v.Stable {
+			logger.Debugf("Found stable version: %s", v.Version)
+
+			return &v, nil
+		}
+	}
+
+	return nil, errNoStableVersion
+}
+
+// fetchVersions fetches and decodes the Go release feed at feedURL, retrying transient failures.
+func fetchVersions(ctx context.Context, feedURL string) ([]GoVersionInfo, error) {
+	var versions []GoVersionInfo
+
+	err := withRetry(ctx, "Fetching the release feed", func() error {
+		var err error
+
+		versions, err = fetchVersionsOnce(ctx, feedURL)
+
+		return err
+	})
+
+	return versions, err
+}
+
+// fetchVersionsOnce fetches and decodes the Go release feed at feedURL.
+func fetchVersionsOnce(ctx context.Context, feedURL string) ([]GoVersionInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClient().Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch version info: %w", err)
+		return nil, fmt.Errorf("failed to fetch version info: %w", classifyRequestError(err))
 	}
 
 	defer func() { _ = resp.Body.Close() }()
 
+	warnOnClockSkew(resp.Header.Get("Date"), time.Now())
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return nil, fmt.Errorf("unexpected status code: %d: %w: %w", resp.StatusCode, errUnexpectedStatus, errServerError)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("unexpected status code: %d: %w", resp.StatusCode, errUnexpectedStatus)
 	}
@@ -169,34 +781,257 @@ func getLatestVersion() (*GoVersionInfo, error) {
 		return nil, fmt.Errorf("failed to decode version info: %w", err)
 	}
 
-	// Find the latest stable version
+	return versions, nil
+}
+
+// classifyRequestError wraps certificate validity errors with ErrClockSkew.
+// A certificate rejected as expired or not yet valid almost always indicates a wrong system clock,
+// so the generic TLS failure is turned into an actionable message. Other errors are returned unchanged.
+func classifyRequestError(err error) error {
+	var certErr x509.CertificateInvalidError
+	if errors.As(err, &certErr) && certErr.Reason == x509.Expired {
+		return fmt.Errorf("%w: %w", ErrClockSkew, err)
+	}
+
+	return err
+}
+
+// warnOnClockSkew logs a warning when the server's Date header differs from the local time by more than maxClockSkew.
+// It reports whether a warning was logged.
+func warnOnClockSkew(dateHeader string, now time.Time) bool {
+	if dateHeader == "" {
+		return false
+	}
+
+	serverTime, err := http.ParseTime(dateHeader)
+	if err != nil {
+		return false
+	}
+
+	skew := now.Sub(serverTime).Abs()
+	if skew <= maxClockSkew {
+		return false
+	}
+
+	logger.Warnf("System clock differs from the server by %s; check that the system date and time are correct",
+		skew.Round(time.Second))
+
+	return true
+}
+
+// DownloadInfo describes where to download a Go archive and how to verify it.
+type DownloadInfo struct {
+	Version  string `json:"version"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Filename string `json:"filename"`
+	URL      string `json:"url"`
+	Sha256   string `json:"sha256"`
+	Sha512   string `json:"sha512,omitempty"`
+	Size     int    `json:"size"`
+}
+
+// ResolveDownload resolves the download URL and SHA-256 checksum of the archive for the given
+// version and platform without downloading it. An empty version selects the latest stable release,
+// and empty goos or goarch default to the current platform. See findVersion for version matching.
+func ResolveDownload(version, goos, goarch string) (*DownloadInfo, error) {
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+
+	var (
+		release *GoVersionInfo
+		err     error
+	)
+
+	if version == "" {
+		release, err = getLatestVersion(context.Background())
+	} else {
+		release, err = GetVersionInfo(version)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve version: %w", err)
+	}
+
+	file, err := selectPlatformFile(release, goos, goarch)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := baseURL()
+	if err != nil {
+		return nil, err
+	}
+
+	return &DownloadInfo{
+		Version:  release.Version,
+		OS:       file.OS,
+		Arch:     file.Arch,
+		Filename: file.Filename,
+		URL:      base + file.Filename,
+		Sha256:   file.Sha256,
+		Sha512:   file.Sha512,
+		Size:     file.Size,
+	}, nil
+}
+
+// findVersion finds the requested version in the release feed, which lists newest releases first.
+// The "go" prefix is optional. An exact match is preferred; otherwise a language version such as
+// "go1.22" selects the newest stable patch release of that series.
+func findVersion(versions []GoVersionInfo, version string) (*GoVersionInfo, error) {
+	if !strings.HasPrefix(version, "go") {
+		version = "go" + version
+	}
+
 	for _, v := range versions {
-		if v.Stable {
-			logger.Debugf("Found stable version: %s", v.Version)
+		if v.Version == version {
+			return &v, nil
+		}
+	}
+
+	for _, v := range versions {
+		if v.Stable && strings.HasPrefix(v.Version, version+".") {
+			logger.Debugf("Resolved %s to %s", version, v.Version)
 
 			return &v, nil
 		}
 	}
 
-	return nil, errNoStableVersion
+	return nil, fmt.Errorf("%s: %w", version, ErrVersionNotFound)
+}
+
+// PrintURL resolves and prints the download URL and SHA-256 checksum for the given version and platform.
+// Plain output prints the URL and checksum on separate lines for use in scripts.
+func PrintURL(version, goos, goarch string, jsonOutput bool) error {
+	info, err := ResolveDownload(version, goos, goarch)
+	if err != nil {
+		logger.Errorf("Error resolving download URL: %v", err)
+
+		return err
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		err = encoder.Encode(info)
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(os.Stdout, info.URL)
+	_, _ = fmt.Fprintln(os.Stdout, info.Sha256)
+
+	return nil
+}
+
+// ListedVersion is a published Go release as reported by PrintVersions.
+type ListedVersion struct {
+	Version   string `json:"version"`
+	Stable    bool   `json:"stable"`
+	Installed bool   `json:"installed"`
+}
+
+// PrintVersions prints the published Go versions, newest first, marking installedVersion if it is listed.
+// Pre-releases are included only when includeUnstable is set. Plain output prints one version per line,
+// prefixed with "* " for the installed version.
+func PrintVersions(includeUnstable bool, installedVersion string, jsonOutput bool) error {
+	versions, err := ListVersions(includeUnstable)
+	if err != nil {
+		logger.Errorf("Error listing Go versions: %v", err)
+
+		return err
+	}
+
+	return writeVersions(os.Stdout, listedVersions(versions, installedVersion), jsonOutput)
+}
+
+// listedVersions converts versions to ListedVersion, marking the one equal to installedVersion.
+func listedVersions(versions []GoVersionInfo, installedVersion string) []ListedVersion {
+	listed := make([]ListedVersion, 0, len(versions))
+
+	for _, version := range versions {
+		listed = append(listed, ListedVersion{
+			Version:   version.Version,
+			Stable:    version.Stable,
+			Installed: installedVersion != "" && version.Version == installedVersion,
+		})
+	}
+
+	return listed
+}
+
+// writeVersions writes listed to writer as indented JSON or as one line per version.
+func writeVersions(writer io.Writer, listed []ListedVersion, jsonOutput bool) error {
+	if jsonOutput {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+
+		err := encoder.Encode(listed)
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+
+		return nil
+	}
+
+	for _, version := range listed {
+		marker := "  "
+		if version.Installed {
+			marker = "* "
+		}
+
+		suffix := ""
+		if !version.Stable {
+			suffix = " (unstable)"
+		}
+
+		_, err := fmt.Fprintf(writer, "%s%s%s\n", marker, version.Version, suffix)
+		if err != nil {
+			return fmt.Errorf("failed to write versions: %w", err)
+		}
+	}
+
+	return nil
 }
 
 // getPlatformFile finds the archive file for the current platform from the version info.
 func getPlatformFile(version *GoVersionInfo) (*goFileInfo, error) {
-	goos := runtime.GOOS
-	goarch := runtime.GOARCH
+	return selectPlatformFile(version, runtime.GOOS, runtime.GOARCH)
+}
 
+// selectPlatformFile finds the archive file for the given platform from the version info.
+// The feed lists archive, installer, and source kinds for each version; only files of kind
+// "archive" are selected, and ErrNoArchiveForPlatform is returned when none matches.
+func selectPlatformFile(version *GoVersionInfo, goos, goarch string) (*goFileInfo, error) {
 	logger.Debugf("Looking for archive for platform: %s/%s", goos, goarch)
 
 	for _, file := range version.Files {
-		if file.OS == goos && file.Arch == goarch && file.Kind == "archive" {
+		switch {
+		case file.Kind == fileKindSource:
+			logger.Debugf("Skipping source file: %s", file.Filename)
+		case file.OS != goos || file.Arch != goarch:
+			continue
+		case file.Kind == fileKindArchive:
 			logger.Debugf("Found matching archive: %s", file.Filename)
 
 			return &file, nil
+		case file.Kind == fileKindInstaller:
+			logger.Debugf("Skipping installer file, installers are not supported: %s", file.Filename)
+		default:
+			logger.Debugf("Skipping file of unknown kind %q: %s", file.Kind, file.Filename)
 		}
 	}
 
-	return nil, fmt.Errorf("no archive found for %s/%s: %w", goos, goarch, errNoArchive)
+	return nil, fmt.Errorf("no archive found for %s/%s: %w", goos, goarch, ErrNoArchiveForPlatform)
 }
 
 // getSearchDirectories determines the directories to search for existing archives.
@@ -307,7 +1142,7 @@ func isReadableDir(dir string) bool {
 // checkExistingArchive checks if the archive already exists at the given path and verifies its checksum.
 // It returns true if the archive exists and is valid, false otherwise.
 // If the archive exists but checksum is invalid, it removes the file.
-func checkExistingArchive(destPath, expectedSha256 string) bool {
+func checkExistingArchive(destPath string, expected Checksum) bool {
 	logger.Debugf("Checking if archive already exists at: %s", destPath)
 
 	_, err := os.Stat(destPath)
@@ -325,7 +1160,7 @@ func checkExistingArchive(destPath, expectedSha256 string) bool {
 
 	logger.Debug("Archive exists, verifying checksum")
 
-	err = verifyChecksum(destPath, expectedSha256)
+	err = verifyChecksum(destPath, expected)
 	if err != nil {
 		logger.Debug("Existing archive checksum verification failed, removing invalid file")
 
@@ -340,18 +1175,27 @@ func checkExistingArchive(destPath, expectedSha256 string) bool {
 }
 
 // downloadAndVerify downloads the file from the given URL to the destination path and verifies its checksum.
+// The checksum is computed while the file is written, so the download is not read back from disk.
 // It removes the file if verification fails.
-func downloadAndVerify(url, destPath, expectedSha256 string) error {
+func downloadAndVerify(ctx context.Context, url, destPath string, expected Checksum) error {
 	logger.Debugf("Downloading from URL: %s to %s", url, destPath)
 
-	err := downloadFile(url, destPath)
+	var actual string
+
+	err := withRetry(ctx, "Downloading "+filepath.Base(destPath), func() error {
+		var err error
+
+		actual, err = downloadFile(ctx, url, destPath, expected.Algorithm)
+
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to download file: %w", err)
 	}
 
 	logger.Debug("Download completed, verifying checksum")
 
-	err = verifyChecksum(destPath, expectedSha256)
+	err = compareChecksum(actual, expected)
 	if err != nil {
 		logger.Debug("Checksum verification failed, cleaning up")
 
@@ -365,11 +1209,186 @@ func downloadAndVerify(url, destPath, expectedSha256 string) error {
 	return nil
 }
 
+// GetSignature downloads the detached OpenPGP signature published alongside the archive at archivePath.
+// The signature is fetched from the release site by the archive's filename and saved next to it
+// with an ".asc" suffix. It returns the path to the signature file.
+func GetSignature(archivePath string) (string, error) {
+	base, err := baseURL()
+	if err != nil {
+		return "", err
+	}
+
+	return getSignature(base+filepath.Base(archivePath)+".asc", archivePath+".asc")
+}
+
+// baseURL returns the base URL for the release feed and archive downloads, with a trailing slash.
+// GO_UPDATER_BASE_URL overrides the default so that an internal mirror of go.dev/dl can be used;
+// the mirror must serve the JSON feed and the archives under the same path.
+func baseURL() (string, error) {
+	base := os.Getenv(BaseURLEnv)
+	if base == "" {
+		return defaultBaseURL, nil
+	}
+
+	parsed, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("%s=%q: %w: %w", BaseURLEnv, base, ErrInvalidBaseURL, err)
+	}
+
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("%s=%q: must be an http or https URL: %w", BaseURLEnv, base, ErrInvalidBaseURL)
+	}
+
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", fmt.Errorf("%s=%q: must not contain a query or fragment: %w", BaseURLEnv, base, ErrInvalidBaseURL)
+	}
+
+	if !strings.HasSuffix(base, "/") {
+		base += "/"
+	}
+
+	logger.Debugf("Using release mirror %s", base)
+
+	return base, nil
+}
+
+// getSignature downloads the signature at url to sigPath.
+func getSignature(url, sigPath string) (string, error) {
+	logger.Debugf("Downloading signature from %s", url)
+
+	req, err := createDownloadRequest(context.Background(), url)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := executeDownloadRequest(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	out, err := createDestinationFile(sigPath)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() { _ = out.Close() }()
+
+	_, err = io.Copy(out, io.LimitReader(resp.Body, maxSignatureSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to save signature: %w", err)
+	}
+
+	return sigPath, nil
+}
+
+// downloadResumable downloads the file to destPath via a ".partial" file that survives interruptions.
+// If a partial file exists, only the remaining bytes are requested with a Range header and appended.
+// A server that ignores the range restarts the download. The checksum is verified before the partial
+// file is renamed to destPath; on mismatch the partial file is removed so the next attempt starts over.
+// Only the previously downloaded bytes are read back to compute the checksum; new bytes are hashed as they arrive.
+func downloadResumable(ctx context.Context, url, destPath string, expected Checksum) error {
+	partialPath := destPath + ".partial"
+
+	var offset int64
+
+	info, err := os.Stat(partialPath)
+	if err == nil {
+		offset = info.Size()
+	}
+
+	req, err := createDownloadRequest(ctx, url)
+	if err != nil {
+		return err
+	}
+
+	if offset > 0 {
+		logger.Infof("Resuming download from byte %d", offset)
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download: %w", classifyRequestError(err))
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	flags := os.O_CREATE | os.O_RDWR
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+	case http.StatusOK:
+		flags |= os.O_TRUNC
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial file already holds every byte; verify it below.
+		logger.Debug("Server reports the partial download is already complete")
+	default:
+		return fmt.Errorf("download failed with status: %d: %w", resp.StatusCode, errDownloadFailed)
+	}
+
+	if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		err = verifyChecksum(partialPath, expected)
+	} else {
+		var actual string
+
+		actual, err = appendResponse(resp, partialPath, flags, expected.Algorithm)
+		if err != nil {
+			return fmt.Errorf("failed to download file, rerun to resume: %w", err)
+		}
+
+		err = compareChecksum(actual, expected)
+	}
+
+	if err != nil {
+		_ = os.Remove(partialPath)
+
+		return fmt.Errorf("checksum verification failed: %w", err)
+	}
+
+	err = os.Rename(partialPath, destPath)
+	if err != nil {
+		return fmt.Errorf("failed to move completed download into place: %w", err)
+	}
+
+	return nil
+}
+
+// appendResponse writes the response body to the file at path, opened with the given flags, and returns
+// the hex-encoded checksum of the whole file computed with algorithm. When flags include os.O_APPEND, the
+// existing contents are read once to seed the checksum; the appended bytes are hashed as they are written.
+func appendResponse(resp *http.Response, path string, flags int, algorithm ChecksumAlgorithm) (string, error) {
+	out, err := os.OpenFile(path, flags, downloadFilePerm) //nolint:gosec // path is built from destDir
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+
+	defer func() { _ = out.Close() }()
+
+	hasher := algorithm.newHash()
+
+	if flags&os.O_APPEND != 0 {
+		_, err = io.Copy(hasher, out)
+		if err != nil {
+			return "", fmt.Errorf("failed to read partial download: %w", err)
+		}
+	}
+
+	err = copyResponse(resp, io.MultiWriter(out, hasher))
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
 // createDownloadRequest creates an HTTP GET request for the given URL with context.
-func createDownloadRequest(url string) (*http.Request, error) {
+func createDownloadRequest(ctx context.Context, url string) (*http.Request, error) {
 	logger.Debugf("Creating HTTP request for: %s", url)
 
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -380,9 +1399,15 @@ func createDownloadRequest(url string) (*http.Request, error) {
 // executeDownloadRequest executes the HTTP request and returns the response.
 // It ensures the response body is closed on error.
 func executeDownloadRequest(req *http.Request) (*http.Response, error) {
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClient().Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to download: %w", err)
+		return nil, fmt.Errorf("failed to download: %w", classifyRequestError(err))
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		defer func() { _ = resp.Body.Close() }()
+
+		return nil, fmt.Errorf("download failed with status: %d: %w: %w", resp.StatusCode, errDownloadFailed, errServerError)
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -410,8 +1435,8 @@ func createDestinationFile(destPath string) (*os.File, error) {
 }
 
 // downloadWithoutProgress copies data from the response body to the file without progress tracking.
-func downloadWithoutProgress(resp *http.Response, out *os.File) error {
-	logger.Debug("Content length unknown, falling back to simple copy")
+func downloadWithoutProgress(resp *http.Response, out io.Writer) error {
+	logger.Debug("Downloading without a progress bar")
 
 	_, err := io.Copy(out, resp.Body)
 	if err != nil {
@@ -424,7 +1449,7 @@ func downloadWithoutProgress(resp *http.Response, out *os.File) error {
 }
 
 // downloadWithProgress sets up a progress bar and copies data with progress tracking.
-func downloadWithProgress(resp *http.Response, out *os.File, contentLength int64) error {
+func downloadWithProgress(resp *http.Response, out io.Writer, contentLength int64) error {
 	logger.Debugf("Content length: %d bytes", contentLength)
 
 	// Create progress bar with description
@@ -457,38 +1482,40 @@ func downloadWithProgress(resp *http.Response, out *os.File, contentLength int64
 }
 
 // downloadFile downloads a file from the given URL to the specified path with progress tracking.
-// It displays download speed, ETA, and completion percentage using a progress bar.
-func downloadFile(url, destPath string) error {
-	req, err := createDownloadRequest(url)
+// On a terminal it displays download speed, ETA, and completion percentage using a progress bar.
+// It returns the hex-encoded checksum of the downloaded bytes, computed with algorithm as they are written.
+func downloadFile(ctx context.Context, url, destPath string, algorithm ChecksumAlgorithm) (string, error) {
+	req, err := createDownloadRequest(ctx, url)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	resp, err := executeDownloadRequest(req)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	defer func() { _ = resp.Body.Close() }()
 
 	out, err := createDestinationFile(destPath)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	defer func() { _ = out.Close() }()
 
-	// Get content length for progress bar
-	contentLength := resp.ContentLength
-	if contentLength <= 0 {
-		return downloadWithoutProgress(resp, out)
+	hasher := algorithm.newHash()
+
+	err = copyResponse(resp, io.MultiWriter(out, hasher))
+	if err != nil {
+		return "", err
 	}
 
-	return downloadWithProgress(resp, out, contentLength)
+	return hex.EncodeToString(hasher.Sum(nil)), nil
 }
 
-// verifyChecksum computes the SHA256 checksum of the file and compares it to the expected value.
-func verifyChecksum(filePath, expectedSha256 string) error {
+// verifyChecksum computes the checksum of the file with the expected algorithm and compares it to the expected value.
+func verifyChecksum(filePath string, expected Checksum) error {
 	logger.Debugf("Verifying checksum for file: %s", filePath)
 
 	file, err := os.Open(filePath) //nolint:gosec
@@ -500,20 +1527,25 @@ func verifyChecksum(filePath, expectedSha256 string) error {
 
 	// gosec: G304 - Potential file inclusion via variable is acceptable here as we control the filePath
 
-	hasher := sha256.New()
+	hasher := expected.Algorithm.newHash()
 
-	logger.Debug("Computing SHA256 hash")
+	logger.Debugf("Computing %s hash", expected.Algorithm)
 
 	_, err = io.Copy(hasher, file)
 	if err != nil {
 		return fmt.Errorf("failed to copy data: %w", err)
 	}
 
-	actualSha256 := hex.EncodeToString(hasher.Sum(nil))
-	logger.Debugf("Computed hash: %s", actualSha256)
+	return compareChecksum(hex.EncodeToString(hasher.Sum(nil)), expected)
+}
+
+// compareChecksum compares a computed hex-encoded checksum to the expected one, naming the algorithm on mismatch.
+func compareChecksum(actual string, expected Checksum) error {
+	logger.Debugf("Computed %s hash: %s", expected.Algorithm, actual)
 
-	if !strings.EqualFold(actualSha256, expectedSha256) {
-		return fmt.Errorf("checksum mismatch: expected %s, got %s: %w", expectedSha256, actualSha256, errChecksumMismatch)
+	if !strings.EqualFold(actual, expected.Value) {
+		return fmt.Errorf("%s checksum mismatch: expected %s, got %s: %w",
+			expected.Algorithm, expected.Value, actual, ErrChecksumMismatch)
 	}
 
 	logger.Debug("Checksum verification passed")
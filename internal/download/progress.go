@@ -0,0 +1,52 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package download
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// progressMutex protects access to progressDisabled.
+var progressMutex sync.Mutex //nolint:gochecknoglobals
+
+// progressDisabled is set by SetProgress to suppress the download progress bar.
+var progressDisabled bool //nolint:gochecknoglobals
+
+// SetProgress enables or disables the progress bar shown on stderr while an archive downloads.
+// It is disabled for --quiet and --json so that only the requested output is written.
+func SetProgress(enabled bool) {
+	progressMutex.Lock()
+
+	progressDisabled = !enabled
+
+	progressMutex.Unlock()
+}
+
+// showProgress reports whether a download of contentLength bytes gets a progress bar: the size must be known,
+// progress must not be disabled, and stderr must be a terminal, so that logs and CI output stay clean.
+func showProgress(contentLength int64) bool {
+	progressMutex.Lock()
+	disabled := progressDisabled
+	progressMutex.Unlock()
+
+	if disabled || contentLength <= 0 {
+		return false
+	}
+
+	info, err := os.Stderr.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// copyResponse copies the response body to out, with a progress bar when showProgress allows one.
+func copyResponse(resp *http.Response, out io.Writer) error {
+	if !showProgress(resp.ContentLength) {
+		return downloadWithoutProgress(resp, out)
+	}
+
+	return downloadWithProgress(resp, out, resp.ContentLength)
+}
@@ -0,0 +1,82 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package download
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestShowProgress is not parallel because it uses SetProgress, which changes package-level state.
+func TestShowProgress(t *testing.T) {
+	t.Cleanup(func() { SetProgress(true) })
+
+	info, err := os.Stderr.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	terminal := info.Mode()&os.ModeCharDevice != 0
+
+	tests := []struct {
+		name          string
+		enabled       bool
+		contentLength int64
+		expected      bool
+	}{
+		{name: "enabled with known length", enabled: true, contentLength: 100, expected: terminal},
+		{name: "unknown length", enabled: true, contentLength: -1, expected: false},
+		{name: "disabled", enabled: false, contentLength: 100, expected: false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			SetProgress(testCase.enabled)
+
+			got := showProgress(testCase.contentLength)
+			if got != testCase.expected {
+				t.Errorf("showProgress(%d) = %t, want %t", testCase.contentLength, got, testCase.expected)
+			}
+		})
+	}
+}
+
+// TestCopyResponse_ProgressDisabled is not parallel because it uses SetProgress, which changes package-level state.
+func TestCopyResponse_ProgressDisabled(t *testing.T) {
+	SetProgress(false)
+	t.Cleanup(func() { SetProgress(true) })
+
+	content := "archive content"
+	resp := &http.Response{
+		Status:           "",
+		StatusCode:       0,
+		Proto:            "",
+		ProtoMajor:       0,
+		ProtoMinor:       0,
+		Header:           nil,
+		Body:             io.NopCloser(strings.NewReader(content)),
+		ContentLength:    int64(len(content)),
+		TransferEncoding: nil,
+		Close:            false,
+		Uncompressed:     false,
+		Trailer:          nil,
+		Request:          nil,
+		TLS:              nil,
+	}
+
+	var out bytes.Buffer
+
+	err := copyResponse(resp, &out)
+	if err != nil {
+		t.Fatalf("copyResponse() error = %v", err)
+	}
+
+	if out.String() != content {
+		t.Errorf("copyResponse() wrote %q, want %q", out.String(), content)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package download
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nicholas-fedor/goUpdater/internal/logger"
+)
+
+// TempDirEnv is the environment variable naming the directory for downloads and staging, overriding the system
+// temp directory, whose tmpfs may be too small to hold a Go archive.
+const TempDirEnv = "GO_UPDATER_TMPDIR"
+
+// TempDirPrefix begins the name of every temporary directory goUpdater creates in TempDir.
+const TempDirPrefix = "goUpdater-"
+
+// StaleTempDirAge is how old a temporary directory left behind by an earlier run must be before
+// RemoveStaleTempDirs removes it. It is far longer than any update takes, so a concurrent run's
+// directory is not removed from under it.
+const StaleTempDirAge = 24 * time.Hour
+
+// tempDirMutex protects access to tempDirOverride.
+var tempDirMutex sync.Mutex //nolint:gochecknoglobals
+
+// tempDirOverride is the directory set by SetTempDir, which takes precedence over TempDirEnv.
+var tempDirOverride string //nolint:gochecknoglobals
+
+// ErrInvalidTempDir indicates that the configured temp directory does not exist or is not a directory.
+var ErrInvalidTempDir = errors.New("invalid temp directory")
+
+// SetTempDir sets the directory in which later downloads and staging directories are created.
+// An empty dir falls back to GO_UPDATER_TMPDIR, then to the system temp directory.
+func SetTempDir(dir string) {
+	tempDirMutex.Lock()
+
+	tempDirOverride = dir
+
+	tempDirMutex.Unlock()
+}
+
+// TempDir returns the directory in which goUpdater creates its temporary directories: the one set with
+// SetTempDir, else GO_UPDATER_TMPDIR, else the system temp directory. A configured directory must exist.
+func TempDir() (string, error) {
+	tempDirMutex.Lock()
+	dir := tempDirOverride
+	tempDirMutex.Unlock()
+
+	source := "--tmpdir"
+
+	if dir == "" {
+		dir, source = os.Getenv(TempDirEnv), TempDirEnv
+	}
+
+	if dir == "" {
+		return os.TempDir(), nil
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", fmt.Errorf("%s=%q: %w: %w", source, dir, ErrInvalidTempDir, err)
+	}
+
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s=%q: not a directory: %w", source, dir, ErrInvalidTempDir)
+	}
+
+	return dir, nil
+}
+
+// MkdirTemp creates a new temporary directory in TempDir, as os.MkdirTemp does with pattern.
+// The caller must remove it.
+func MkdirTemp(pattern string) (string, error) {
+	parent, err := TempDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir, err := os.MkdirTemp(parent, pattern)
+	if err != nil {
+		return "", fmt.Errorf("%w (set --tmpdir or %s to a writable directory)", err, TempDirEnv)
+	}
+
+	return dir, nil
+}
+
+// RemoveStaleTempDirs removes the TempDirPrefix directories in TempDir that were last modified more than maxAge ago.
+// They are left behind when a run is killed before its deferred cleanup runs, and may each hold a Go archive.
+// Directories that cannot be removed, such as those of another user, are skipped. It returns how many were removed.
+func RemoveStaleTempDirs(maxAge time.Duration) (int, error) {
+	parent, err := TempDir()
+	if err != nil {
+		return 0, err
+	}
+
+	entries, err := os.ReadDir(parent)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list %s: %w", parent, err)
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	removed := 0
+
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), TempDirPrefix) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(cutoff) {
+			continue
+		}
+
+		path := filepath.Join(parent, entry.Name())
+
+		err = os.RemoveAll(path)
+		if err != nil {
+			logger.Debugf("Cannot remove stale temp directory %s: %v", path, err)
+
+			continue
+		}
+
+		logger.Debugf("Removed stale temp directory %s", path)
+
+		removed++
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,123 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package download
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestTempDir is not parallel because it uses t.Setenv and SetTempDir, which change process-wide state.
+func TestTempDir(t *testing.T) {
+	envDir := t.TempDir()
+	flagDir := t.TempDir()
+	file := filepath.Join(t.TempDir(), "file")
+
+	err := os.WriteFile(file, nil, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		override string
+		env      string
+		expected string
+		wantErr  bool
+	}{
+		{name: "system default", override: "", env: "", expected: os.TempDir(), wantErr: false},
+		{name: "environment", override: "", env: envDir, expected: envDir, wantErr: false},
+		{name: "override wins", override: flagDir, env: envDir, expected: flagDir, wantErr: false},
+		{name: "missing", override: filepath.Join(flagDir, "missing"), env: "", expected: "", wantErr: true},
+		{name: "not a directory", override: "", env: file, expected: "", wantErr: true},
+	}
+
+	t.Cleanup(func() { SetTempDir("") })
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv(TempDirEnv, testCase.env)
+			SetTempDir(testCase.override)
+
+			dir, err := TempDir()
+			if testCase.wantErr != errors.Is(err, ErrInvalidTempDir) {
+				t.Fatalf("TempDir() error = %v, wantErr %t", err, testCase.wantErr)
+			}
+
+			if dir != testCase.expected {
+				t.Errorf("TempDir() = %q, want %q", dir, testCase.expected)
+			}
+		})
+	}
+}
+
+// TestMkdirTemp is not parallel because it uses SetTempDir, which changes package-level state.
+func TestMkdirTemp(t *testing.T) {
+	parent := t.TempDir()
+
+	SetTempDir(parent)
+	t.Cleanup(func() { SetTempDir("") })
+
+	dir, err := MkdirTemp("goUpdater-*")
+	if err != nil {
+		t.Fatalf("MkdirTemp() error = %v", err)
+	}
+
+	if filepath.Dir(dir) != parent || !strings.HasPrefix(filepath.Base(dir), "goUpdater-") {
+		t.Errorf("MkdirTemp() = %q, want a goUpdater-* directory in %q", dir, parent)
+	}
+}
+
+// TestRemoveStaleTempDirs is not parallel because it uses SetTempDir, which changes package-level state.
+func TestRemoveStaleTempDirs(t *testing.T) {
+	parent := t.TempDir()
+
+	SetTempDir(parent)
+	t.Cleanup(func() { SetTempDir("") })
+
+	old := time.Now().Add(-2 * StaleTempDirAge)
+
+	dirs := map[string]bool{
+		"goUpdater-stale":         false,
+		"goUpdater-install-stale": false,
+		"goUpdater-recent":        true,
+		"unrelated-stale":         true,
+	}
+
+	for name := range dirs {
+		path := filepath.Join(parent, name)
+
+		err := os.Mkdir(path, 0700)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if strings.HasSuffix(name, "-stale") {
+			err = os.Chtimes(path, old, old)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	removed, err := RemoveStaleTempDirs(StaleTempDirAge)
+	if err != nil {
+		t.Fatalf("RemoveStaleTempDirs() error = %v", err)
+	}
+
+	if removed != 2 {
+		t.Errorf("RemoveStaleTempDirs() removed %d directories, want 2", removed)
+	}
+
+	for name, wantKept := range dirs {
+		_, err := os.Stat(filepath.Join(parent, name))
+		if kept := err == nil; kept != wantKept {
+			t.Errorf("%s kept = %t, want %t", name, kept, wantKept)
+		}
+	}
+}
@@ -7,26 +7,60 @@ package install
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"os/user"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/nicholas-fedor/goUpdater/internal/archive"
 	"github.com/nicholas-fedor/goUpdater/internal/download"
 	"github.com/nicholas-fedor/goUpdater/internal/logger"
 	"github.com/nicholas-fedor/goUpdater/internal/privileges"
+	"github.com/nicholas-fedor/goUpdater/internal/uninstall"
 	"github.com/nicholas-fedor/goUpdater/internal/verify"
-	"github.com/nicholas-fedor/goUpdater/internal/version"
 )
 
-const directoryPermissions = 0755 // Default directory permissions for installation
+const (
+	directoryPermissions = 0755 // Default directory permissions for installation
+	executableMask       = 0111 // Any execute permission bit
+)
+
+// archiveRootDir is the top-level directory of every official Go archive.
+const archiveRootDir = "go"
+
+// ErrGoStillShadowed indicates another go binary resolves on PATH ahead of the installed one.
+var ErrGoStillShadowed = errors.New("go on PATH does not resolve to the installed binary")
+
+// ErrGoNotOnPath indicates no go binary resolves on PATH.
+var ErrGoNotOnPath = errors.New("go is not on PATH")
+
+// ErrInsufficientSpace indicates there is not enough free disk space to extract the archive.
+var ErrInsufficientSpace = errors.New("insufficient disk space")
+
+// errDestinationInSource indicates a copy destination lies inside the tree being copied.
+var errDestinationInSource = errors.New("destination is inside the source directory")
+
+// errInvalidOwner indicates an invalid "user[:group]" owner specification.
+var errInvalidOwner = errors.New("invalid owner")
 
 // Install installs Go to the specified directory, either from the latest version or from a provided archive.
-// It handles privilege elevation, existing installation checks, and all output messaging.
+// It handles privilege elevation when installDir is not user-writable, existing installation checks,
+// and all output messaging.
 // The installDir should typically be "/usr/local/go". If archivePath is empty, the latest version is installed.
-func Install(installDir, archivePath string) error {
-	logger.Debugf("Starting InstallGo: installDir=%s, archivePath=%s", installDir, archivePath)
+// A checksum with a non-empty Value is the expected digest of the archive at archivePath, which is verified
+// before anything is extracted.
+// Archive entries matching any of the excludes globs are skipped (see archive.ExtractWithExcludes).
+func Install(installDir, archivePath string, checksum download.Checksum, excludes []string) error {
+	logger.Debugf("Starting InstallGo: installDir=%s, archivePath=%s, excludes=%v", installDir, archivePath, excludes)
 
 	// Check if Go is already installed
 	installedVersion, err := verify.GetInstalledVersion(installDir)
@@ -38,19 +72,118 @@ func Install(installDir, archivePath string) error {
 
 	if archivePath == "" {
 		// Install latest version
-		err = privileges.ElevateAndExecute(func() error { return Latest(installDir) })
+		err = privileges.ElevateIfRequired(installDir, func() error { return latest(installDir, excludes) })
+		if err != nil {
+			return fmt.Errorf("failed to install latest Go: %w", err)
+		}
 
-		return fmt.Errorf("failed to install latest Go: %w", err)
+		return nil
 	}
 	// Install from archive
-	err = privileges.ElevateAndExecute(func() error { return GoWithVerification(archivePath, installDir) })
+	err = privileges.ElevateIfRequired(installDir, func() error {
+		if checksum.Value != "" {
+			err := download.VerifyChecksum(archivePath, checksum)
+			if err != nil {
+				return fmt.Errorf("archive integrity check failed: %w", err)
+			}
+
+			logger.Infof("Verified the %s checksum of %s", checksum.Algorithm, archivePath)
+		}
+
+		return goWithVerification(archivePath, installDir, excludes)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to install Go from archive: %w", err)
+	}
 
-	return fmt.Errorf("failed to install Go from archive: %w", err)
+	return nil
+}
+
+// ParseOwner resolves an owner specification of the form "user[:group]" to numeric IDs.
+// Both names and numeric IDs are accepted. When the group is omitted, the user's primary group is used.
+func ParseOwner(spec string) (int, int, error) {
+	userPart, groupPart, hasGroup := strings.Cut(spec, ":")
+	if userPart == "" || (hasGroup && groupPart == "") {
+		return 0, 0, fmt.Errorf("%w: %q, expected user[:group]", errInvalidOwner, spec)
+	}
+
+	owner, err := user.Lookup(userPart)
+	if err != nil {
+		owner, err = user.LookupId(userPart)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to look up user %s: %w", userPart, err)
+		}
+	}
+
+	uid, err := strconv.Atoi(owner.Uid)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: non-numeric uid %s for user %s", errInvalidOwner, owner.Uid, userPart)
+	}
+
+	gidStr := owner.Gid
+
+	if hasGroup {
+		group, err := user.LookupGroup(groupPart)
+		if err != nil {
+			group, err = user.LookupGroupId(groupPart)
+			if err != nil {
+				return 0, 0, fmt.Errorf("failed to look up group %s: %w", groupPart, err)
+			}
+		}
+
+		gidStr = group.Gid
+	}
+
+	gid, err := strconv.Atoi(gidStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: non-numeric gid %s", errInvalidOwner, gidStr)
+	}
+
+	return uid, gid, nil
+}
+
+// ChownTree changes the owner of root and every entry beneath it to uid and gid.
+// Symbolic links are changed themselves rather than the files they point to.
+// It is intended to run after a successful installation while still elevated.
+func ChownTree(root string, uid, gid int) error {
+	logger.Debugf("Changing ownership of %s to %d:%d", root, uid, gid)
+
+	err := filepath.WalkDir(root, func(path string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		return os.Lchown(path, uid, gid)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to change ownership of %s: %w", root, err)
+	}
+
+	logger.Infof("Changed ownership of %s to %d:%d", root, uid, gid)
+
+	return nil
 }
 
 // Go extracts the Go archive to the specified installation directory.
 // The installDir should typically be "/usr/local/go".
 func Go(archivePath, installDir string) error {
+	return GoWithExcludes(archivePath, installDir, nil)
+}
+
+// GoWithExcludes extracts the Go archive to the specified installation directory,
+// skipping archive entries that match any of the excludes globs.
+// The installDir should typically be "/usr/local/go".
+func GoWithExcludes(archivePath, installDir string, excludes []string) error {
+	return goWithExcludes(context.Background(), archivePath, installDir, excludes)
+}
+
+// GoContext behaves like Go, but stops extracting once ctx is done, leaving installDir untouched.
+func GoContext(ctx context.Context, archivePath, installDir string) error {
+	return goWithExcludes(ctx, archivePath, installDir, nil)
+}
+
+// goWithExcludes implements GoWithExcludes, stopping the extraction once ctx is done.
+func goWithExcludes(ctx context.Context, archivePath, installDir string, excludes []string) error {
 	logger.Debugf("Starting Go installation: archive=%s, installDir=%s",
 		archivePath, installDir)
 
@@ -64,13 +197,40 @@ func Go(archivePath, installDir string) error {
 		return err
 	}
 
-	logger.Debugf("Extracting archive to: %s", filepath.Dir(installDir))
+	if len(excludes) > 0 {
+		logger.Warnf("Skipping archive entries matching %v; the first build may be slower", excludes)
+	}
+
+	stagingDir, err := createStagingDir(installDir)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = os.RemoveAll(stagingDir) }()
 
-	err = archive.Extract(archivePath, filepath.Dir(installDir))
+	err = checkSpace(archivePath, stagingDir, installDir)
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf("Extracting archive to: %s", stagingDir)
+
+	start := time.Now()
+
+	extractor := archive.NewExtractor(archive.WithExcludes(excludes), archive.WithDurableWrites(true))
+
+	err = extractor.ExtractContext(ctx, archivePath, stagingDir)
 	if err != nil {
 		return fmt.Errorf("failed to extract archive: %w", err)
 	}
 
+	logger.Debugf("Extracted archive in %s", time.Since(start).Round(time.Millisecond))
+
+	err = swapInstallDir(filepath.Join(stagingDir, archiveRootDir), installDir)
+	if err != nil {
+		return err
+	}
+
 	logger.Debug("Go installation completed successfully")
 
 	return nil
@@ -80,15 +240,25 @@ func Go(archivePath, installDir string) error {
 // and verifies the installation afterwards.
 // The installDir should typically be "/usr/local/go".
 func GoWithVerification(archivePath, installDir string) error {
+	return goWithVerification(archivePath, installDir, nil)
+}
+
+// goWithVerification extracts the Go archive, skipping entries matching excludes,
+// and verifies the installation afterwards.
+func goWithVerification(archivePath, installDir string, excludes []string) error {
 	logger.Debugf("Starting Go installation with verification: archive=%s, installDir=%s",
 		archivePath, installDir)
 
-	err := Go(archivePath, installDir)
+	err := GoWithExcludes(archivePath, installDir, excludes)
 	if err != nil {
 		return err
 	}
 
-	expectedVersion := archive.ExtractVersion(archivePath)
+	expectedVersion, err := releaseVersion(archivePath, installDir)
+	if err != nil {
+		return fmt.Errorf("installation verification failed: %w", err)
+	}
+
 	logger.Debugf("Expected version from archive: %s", expectedVersion)
 
 	err = verify.Installation(installDir, expectedVersion)
@@ -98,15 +268,111 @@ func GoWithVerification(archivePath, installDir string) error {
 
 	logger.Infof("Go successfully installed to %s", installDir)
 
+	ReportPathResolution(installDir)
+
 	return nil
 }
 
+// releaseVersion returns the Go release an installation from archivePath must report: the version in a
+// standard archive name such as go1.21.0.linux-amd64.tar.gz, or, for an archive with another name, the
+// VERSION file of the tree extracted to installDir.
+func releaseVersion(archivePath, installDir string) (string, error) {
+	name := filepath.Base(archivePath)
+	for _, ext := range []string{".tar.gz", ".tar.zst", ".zip"} {
+		name = strings.TrimSuffix(name, ext)
+	}
+
+	// The platform follows the last dot, as in go1.24rc1.linux-amd64
+	if index := strings.LastIndex(name, "."); index > 0 && download.IsGoVersion(name[:index]) {
+		return name[:index], nil
+	}
+
+	version, err := verify.ReadVersionFile(installDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine the Go version of %s: %w", archivePath, err)
+	}
+
+	return version, nil
+}
+
+// VerifyPathResolution confirms that "go" on PATH resolves to the binary in installDir.
+// It returns ErrGoStillShadowed naming the binary that resolves first when another installation
+// shadows it, and ErrGoNotOnPath when no go binary is found on PATH at all.
+func VerifyPathResolution(installDir string) error {
+	return verifyPathResolution(installDir, os.Getenv("PATH"))
+}
+
+// verifyPathResolution checks which go binary the given PATH value resolves to.
+// PATH is searched directly rather than through exec.LookPath so the check does not
+// depend on, or modify, the process environment.
+func verifyPathResolution(installDir, pathEnv string) error {
+	goBinary := "go"
+	if runtime.GOOS == "windows" {
+		goBinary += ".exe"
+	}
+
+	expected, err := filepath.EvalSymlinks(filepath.Join(installDir, "bin", goBinary))
+	if err != nil {
+		return fmt.Errorf("failed to resolve installed go binary: %w", err)
+	}
+
+	for _, dir := range filepath.SplitList(pathEnv) {
+		if dir == "" {
+			continue
+		}
+
+		candidate := filepath.Join(dir, goBinary)
+
+		info, err := os.Stat(candidate)
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&executableMask == 0 {
+			continue
+		}
+
+		resolved, err := filepath.EvalSymlinks(candidate)
+		if err != nil {
+			continue
+		}
+
+		if resolved == expected {
+			return nil
+		}
+
+		return fmt.Errorf("%s resolves first, not %s: %w", candidate, expected, ErrGoStillShadowed)
+	}
+
+	return fmt.Errorf("add %s to PATH: %w", filepath.Join(installDir, "bin"), ErrGoNotOnPath)
+}
+
+// ReportPathResolution logs whether the installed go binary is the one found on PATH.
+// The check is skipped under sudo, whose secure_path differs from the invoking user's PATH.
+func ReportPathResolution(installDir string) {
+	if os.Getenv("SUDO_USER") != "" {
+		logger.Debug("Skipping PATH resolution check under sudo")
+
+		return
+	}
+
+	err := VerifyPathResolution(installDir)
+	if err != nil {
+		logger.Warnf("Installed Go may not be used by default: %v", err)
+
+		return
+	}
+
+	logger.Debugf("go on PATH resolves to %s", installDir)
+}
+
 // Latest downloads the latest Go version and installs it to the specified directory.
 // The installDir should typically be "/usr/local/go".
 func Latest(installDir string) error {
+	return latest(installDir, nil)
+}
+
+// latest downloads the latest Go version and installs it, skipping entries matching excludes.
+func latest(installDir string, excludes []string) error {
 	logger.Debugf("Starting latest Go installation: installDir=%s", installDir)
 
-	tempDir, err := os.MkdirTemp("", "goUpdater-install-*")
+	tempDir, err := download.MkdirTemp(download.TempDirPrefix + "install-*")
 	if err != nil {
 		return fmt.Errorf("failed to create temp directory: %w", err)
 	}
@@ -120,7 +386,7 @@ func Latest(installDir string) error {
 
 	logger.Debugf("Downloaded archive: %s", archivePath)
 
-	err = GoWithVerification(archivePath, installDir)
+	err = goWithVerification(archivePath, installDir, excludes)
 	if err != nil {
 		return err
 	}
@@ -141,7 +407,7 @@ func HandleExistingInstallation(_ string, installedVersion string) {
 	latestVersion := strings.TrimPrefix(latestVersionInfo.Version, "go")
 
 	// Compare versions
-	if version.Compare(installedVersion, latestVersion) >= 0 {
+	if download.CompareGoVersions(installedVersion, latestVersion) >= 0 {
 		logger.Infof("Go (%s) is already installed.", strings.TrimPrefix(installedVersion, "go"))
 
 		return
@@ -184,3 +450,299 @@ func prepareInstallDir(installDir string) error {
 
 	return nil
 }
+
+// createStagingDir creates the directory the archive is extracted into before being swapped into place.
+// It prefers a sibling of installDir, on the same filesystem, so the final rename is atomic and cheap.
+// When the parent directory is not writable, it falls back to download.TempDir,
+// and swapInstallDir copies the tree across filesystems.
+func createStagingDir(installDir string) (string, error) {
+	stagingDir, err := os.MkdirTemp(filepath.Dir(installDir), ".goUpdater-staging-*")
+	if err == nil {
+		return stagingDir, nil
+	}
+
+	logger.Debugf("Cannot stage next to %s, falling back to the temporary directory: %v", installDir, err)
+
+	stagingDir, err = download.MkdirTemp(download.TempDirPrefix + "staging-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create staging directory: %w", err)
+	}
+
+	return stagingDir, nil
+}
+
+// checkSpace verifies that the archive's uncompressed contents fit in stagingDir and, when the tree has to be
+// copied across filesystems, next to installDir. The size is read from the compressed stream with
+// archive.UncompressedSize, so the archive is not decompressed an extra time; when the stream does not record
+// it, the check is skipped and the extraction's own size limits apply.
+func checkSpace(archivePath, stagingDir, installDir string) error {
+	size, err := archive.UncompressedSize(archivePath)
+	if err != nil {
+		logger.Debugf("Skipping the disk space check: %v", err)
+
+		return nil
+	}
+
+	required := uint64(size) // #nosec G115 -- sizes are never negative
+
+	for _, dir := range []string{stagingDir, filepath.Dir(installDir)} {
+		err = ensureSpace(dir, required)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureSpace returns ErrInsufficientSpace when the filesystem containing dir has fewer than required bytes free.
+func ensureSpace(dir string, required uint64) error {
+	available, err := AvailableSpace(dir)
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf("Disk space in %s: %d bytes available, %d bytes required", dir, available, required)
+
+	if available < required {
+		return fmt.Errorf("extracting Go needs %d bytes but only %d are available in %s: %w",
+			required, available, dir, ErrInsufficientSpace)
+	}
+
+	return nil
+}
+
+// swapInstallDir moves the staged Go tree to installDir.
+// An existing installDir is first renamed aside and restored if the move fails,
+// so installDir never holds a partially moved tree. An existing installDir that is neither empty
+// nor a Go distribution (see uninstall.IsGoTree) is refused with uninstall.ErrNotGoInstallation,
+// so a mistyped --install-dir never deletes unrelated files.
+func swapInstallDir(stagedDir, installDir string) error {
+	_, err := os.Lstat(installDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return moveDir(stagedDir, installDir)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", installDir, err)
+	}
+
+	entries, err := os.ReadDir(installDir)
+	if (err != nil || len(entries) > 0) && !uninstall.IsGoTree(installDir) {
+		return fmt.Errorf("%s is not empty and holds no Go distribution; refusing to replace it: %w",
+			installDir, uninstall.ErrNotGoInstallation)
+	}
+
+	previousDir := installDir + ".goUpdater-previous"
+
+	err = os.RemoveAll(previousDir)
+	if err != nil {
+		return fmt.Errorf("failed to remove stale %s: %w", previousDir, err)
+	}
+
+	err = os.Rename(installDir, previousDir)
+	if err != nil {
+		return fmt.Errorf("failed to move existing installation aside: %w", err)
+	}
+
+	err = moveDir(stagedDir, installDir)
+	if err != nil {
+		restoreErr := os.Rename(previousDir, installDir)
+		if restoreErr != nil {
+			logger.Errorf("Failed to restore previous installation from %s: %v", previousDir, restoreErr)
+		}
+
+		return err
+	}
+
+	err = os.RemoveAll(previousDir)
+	if err != nil {
+		logger.Warnf("Failed to remove previous installation at %s: %v", previousDir, err)
+	}
+
+	return nil
+}
+
+// renameDir moves a directory in a single step. It is a variable so tests can force the cross-device
+// copy path of moveDir.
+//
+//nolint:gochecknoglobals
+var renameDir = os.Rename
+
+// moveDir renames src to dst, copying the tree and removing src when they are on different filesystems.
+// The copy is made in a sibling of dst, with every file synced, and renamed into place once complete,
+// so a crash during the copy never leaves a partial tree at dst. The copy takes the mode of src, rather
+// than the 0700 of a fresh temporary directory. The parent of dst is synced after the rename so that
+// the move itself is durable.
+func moveDir(src, dst string) error {
+	err := renameDir(src, dst)
+	if err == nil {
+		return archive.SyncDir(filepath.Dir(dst))
+	}
+
+	if !errors.Is(err, syscall.EXDEV) {
+		return fmt.Errorf("failed to move %s to %s: %w", src, dst, err)
+	}
+
+	logger.Debugf("%s and %s are on different filesystems, copying instead", src, dst)
+
+	copyDir, err := os.MkdirTemp(filepath.Dir(dst), "."+filepath.Base(dst)+".copy-*")
+	if err != nil {
+		return fmt.Errorf("failed to create copy of %s next to %s: %w", src, dst, err)
+	}
+
+	err = copyTree(src, copyDir)
+	if err == nil {
+		err = copyRootMode(src, copyDir)
+	}
+
+	if err == nil {
+		err = os.Rename(copyDir, dst)
+	}
+
+	if err != nil {
+		_ = os.RemoveAll(copyDir)
+
+		return fmt.Errorf("failed to copy %s to %s: %w", src, dst, err)
+	}
+
+	err = archive.SyncDir(filepath.Dir(dst))
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(src)
+}
+
+// copyRootMode gives the directory dst the permission bits of src.
+func copyRootMode(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", src, err)
+	}
+
+	err = os.Chmod(dst, info.Mode().Perm())
+	if err != nil {
+		return fmt.Errorf("failed to set mode of %s: %w", dst, err)
+	}
+
+	return nil
+}
+
+// CopyDir recursively copies the directory tree at src to dst, preserving modes. Directories and
+// regular files are copied and symlinks are recreated as-is, never followed, so the copy cannot
+// reach outside src. If src itself is a symlink, such as /usr/local/go pointing at a versioned
+// directory, the tree it resolves to is copied. A dst inside the resolved src is rejected.
+func CopyDir(src, dst string) error {
+	resolvedSrc, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", src, err)
+	}
+
+	absDst, err := filepath.Abs(dst)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", dst, err)
+	}
+
+	// dst usually does not exist yet, so resolve its parent instead.
+	resolvedParent, err := filepath.EvalSymlinks(filepath.Dir(absDst))
+	if err == nil {
+		absDst = filepath.Join(resolvedParent, filepath.Base(absDst))
+	}
+
+	absSrc, err := filepath.Abs(resolvedSrc)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", src, err)
+	}
+
+	rel, err := filepath.Rel(absSrc, absDst)
+	if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("cannot copy %s to %s: %w", src, dst, errDestinationInSource)
+	}
+
+	return copyTree(absSrc, dst)
+}
+
+// copyTree recursively copies directories, regular files, and symlinks from src to dst, preserving modes.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", path, err)
+		}
+
+		target := filepath.Join(dst, relPath)
+
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+
+		switch {
+		case info.IsDir():
+			err = os.MkdirAll(target, info.Mode().Perm())
+		case info.Mode()&fs.ModeSymlink != 0:
+			err = copySymlink(path, target)
+		case info.Mode().IsRegular():
+			err = copyFile(path, target, info.Mode().Perm())
+		default:
+			logger.Debugf("Skipping unsupported file type: %s", path)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to copy %s: %w", path, err)
+		}
+
+		return nil
+	})
+}
+
+// copySymlink recreates the symlink at path as target.
+func copySymlink(path, target string) error {
+	linkname, err := os.Readlink(path)
+	if err != nil {
+		return fmt.Errorf("failed to read symlink: %w", err)
+	}
+
+	return os.Symlink(linkname, target)
+}
+
+// copyFile copies the regular file at path to target with the given permissions.
+func copyFile(path, target string, perm fs.FileMode) error {
+	source, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return fmt.Errorf("failed to open source: %w", err)
+	}
+
+	defer func() { _ = source.Close() }()
+
+	destination, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm) // #nosec G304
+	if err != nil {
+		return fmt.Errorf("failed to create destination: %w", err)
+	}
+
+	_, err = io.Copy(destination, source)
+	if err != nil {
+		_ = destination.Close()
+
+		return fmt.Errorf("failed to copy contents: %w", err)
+	}
+
+	err = destination.Sync()
+	if err != nil {
+		_ = destination.Close()
+
+		return fmt.Errorf("failed to sync destination: %w", err)
+	}
+
+	err = destination.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close destination: %w", err)
+	}
+
+	return os.Chmod(target, perm)
+}
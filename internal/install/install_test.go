@@ -6,10 +6,19 @@ package install
 import (
 	"archive/tar"
 	"compress/gzip"
+	"errors"
+	"math"
 	"os"
+	"os/user"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
 	"testing"
 	"time"
+
+	"github.com/nicholas-fedor/goUpdater/internal/download"
+	"github.com/nicholas-fedor/goUpdater/internal/uninstall"
 )
 
 func createTestArchive(t *testing.T, files map[string]string) string {
@@ -210,6 +219,24 @@ func setupArchiveNotFoundTest(t *testing.T) (string, string) {
 	return "/nonexistent/archive.tar.gz", installDir
 }
 
+func TestInstallChecksumMismatch(t *testing.T) {
+	t.Parallel()
+
+	archivePath, installDir := setupSuccessTest(t)
+
+	checksum := download.Checksum{Algorithm: download.ChecksumSHA256, Value: strings.Repeat("0", 64)}
+
+	err := Install(installDir, archivePath, checksum, nil)
+	if !errors.Is(err, download.ErrChecksumMismatch) {
+		t.Fatalf("Install() error = %v, want %v", err, download.ErrChecksumMismatch)
+	}
+
+	_, err = os.Stat(installDir)
+	if !os.IsNotExist(err) {
+		t.Errorf("expected nothing to be installed after a checksum mismatch, got %v", err)
+	}
+}
+
 func TestPrepareInstallDir(t *testing.T) {
 	t.Parallel()
 
@@ -227,3 +254,449 @@ func TestPrepareInstallDir(t *testing.T) {
 		t.Error("parent directory should exist")
 	}
 }
+
+func TestParseOwner(t *testing.T) {
+	t.Parallel()
+
+	current, err := user.Current()
+	if err != nil {
+		t.Skipf("cannot determine current user: %v", err)
+	}
+
+	wantUID, _ := strconv.Atoi(current.Uid)
+	wantGID, _ := strconv.Atoi(current.Gid)
+
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr bool
+	}{
+		{name: "user name", spec: current.Username, wantErr: false},
+		{name: "numeric uid", spec: current.Uid, wantErr: false},
+		{name: "user and numeric group", spec: current.Username + ":" + current.Gid, wantErr: false},
+		{name: "empty", spec: "", wantErr: true},
+		{name: "empty group", spec: current.Username + ":", wantErr: true},
+		{name: "unknown user", spec: "goupdater-no-such-user", wantErr: true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			uid, gid, err := ParseOwner(testCase.spec)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("ParseOwner(%q) error = %v, wantErr %v", testCase.spec, err, testCase.wantErr)
+			}
+
+			if err == nil && (uid != wantUID || gid != wantGID) {
+				t.Errorf("ParseOwner(%q) = %d:%d, want %d:%d", testCase.spec, uid, gid, wantUID, wantGID)
+			}
+		})
+	}
+}
+
+func TestChownTree(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+
+	err := os.MkdirAll(filepath.Join(root, "bin"), 0750)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(root, "bin", "go"), []byte("binary"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Symlink("bin/go", filepath.Join(root, "go-link"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Changing ownership to the current owner is permitted without privileges
+	err = ChownTree(root, os.Getuid(), os.Getgid())
+	if err != nil {
+		t.Errorf("ChownTree() unexpected error: %v", err)
+	}
+
+	err = ChownTree(filepath.Join(root, "missing"), os.Getuid(), os.Getgid())
+	if err == nil {
+		t.Error("ChownTree() expected error for missing root")
+	}
+}
+
+// TestInvokingOwner is not parallel because it sets the sudo environment variables.
+func TestInvokingOwner(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("InvokingOwner only applies when running as root")
+	}
+
+	const invokerID = 12345
+
+	t.Setenv("SUDO_UID", strconv.Itoa(invokerID))
+	t.Setenv("SUDO_GID", strconv.Itoa(invokerID))
+
+	userDir := t.TempDir()
+
+	err := os.Chown(userDir, invokerID, invokerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uid, gid, ok := InvokingOwner(filepath.Join(userDir, "sdk", "go"))
+	if !ok || uid != invokerID || gid != invokerID {
+		t.Errorf("InvokingOwner() under a user-owned directory = %d, %d, %t, want %d, %d, true",
+			uid, gid, ok, invokerID, invokerID)
+	}
+
+	_, _, ok = InvokingOwner(filepath.Join(t.TempDir(), "go"))
+	if ok {
+		t.Error("InvokingOwner() under a root-owned directory = true, want false")
+	}
+}
+
+func TestCreateStagingDir(t *testing.T) {
+	t.Parallel()
+
+	installDir := filepath.Join(t.TempDir(), "go")
+
+	stagingDir, err := createStagingDir(installDir)
+	if err != nil {
+		t.Fatalf("createStagingDir() error = %v", err)
+	}
+
+	if filepath.Dir(stagingDir) != filepath.Dir(installDir) {
+		t.Errorf("createStagingDir() = %s, want a sibling of %s", stagingDir, installDir)
+	}
+}
+
+func TestSwapInstallDir(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		existing map[string]string
+	}{
+		{name: "fresh install", existing: nil},
+		{name: "replaces empty directory", existing: map[string]string{}},
+		{name: "replaces existing installation", existing: map[string]string{"VERSION": "go1.20.0", "stale": ""}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			parentDir := t.TempDir()
+			installDir := filepath.Join(parentDir, "go")
+			stagedDir := filepath.Join(parentDir, "staged")
+
+			err := os.MkdirAll(filepath.Join(stagedDir, "bin"), 0750)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = os.WriteFile(filepath.Join(stagedDir, "VERSION"), []byte("go1.21.0"), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if testCase.existing != nil {
+				writeTree(t, installDir, testCase.existing)
+			}
+
+			err = swapInstallDir(stagedDir, installDir)
+			if err != nil {
+				t.Fatalf("swapInstallDir() error = %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(installDir, "VERSION"))
+			if err != nil || string(content) != "go1.21.0" {
+				t.Errorf("VERSION = %q, %v, want go1.21.0", content, err)
+			}
+
+			entries, err := os.ReadDir(parentDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(entries) != 1 {
+				t.Errorf("parent directory has %d entries, want only the installation", len(entries))
+			}
+		})
+	}
+}
+
+func TestGoRefusesNonGoDirectory(t *testing.T) {
+	t.Parallel()
+
+	archivePath := createTestArchive(t, map[string]string{"go/VERSION": "go1.21.0", "go/bin/go": "fake go binary"})
+	installDir := filepath.Join(t.TempDir(), "projects")
+	writeTree(t, installDir, map[string]string{"notes.txt": "user data"})
+
+	err := GoWithExcludes(archivePath, installDir, nil)
+	if !errors.Is(err, uninstall.ErrNotGoInstallation) {
+		t.Fatalf("GoWithExcludes() error = %v, want %v", err, uninstall.ErrNotGoInstallation)
+	}
+
+	content, err := os.ReadFile(filepath.Join(installDir, "notes.txt"))
+	if err != nil || string(content) != "user data" {
+		t.Errorf("notes.txt = %q, %v, want the user's file left in place", content, err)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(installDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Errorf("parent directory has %d entries, want only the untouched directory", len(entries))
+	}
+}
+
+// writeTree creates dir holding files, keyed by their path relative to dir.
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	err := os.MkdirAll(dir, 0750)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range files {
+		err = os.WriteFile(filepath.Join(dir, name), []byte(content), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCopyTree(t *testing.T) {
+	t.Parallel()
+
+	src := t.TempDir()
+	dst := filepath.Join(t.TempDir(), "copy")
+
+	err := os.MkdirAll(filepath.Join(src, "bin"), 0750)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(src, "bin", "go"), []byte("go binary"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Symlink("bin/go", filepath.Join(src, "go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = copyTree(src, dst)
+	if err != nil {
+		t.Fatalf("copyTree() error = %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(dst, "bin", "go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Mode().Perm() != 0700 {
+		t.Errorf("copied file mode = %v, want 0700", info.Mode().Perm())
+	}
+
+	linkname, err := os.Readlink(filepath.Join(dst, "go"))
+	if err != nil || linkname != "bin/go" {
+		t.Errorf("copied symlink = %q, %v, want bin/go", linkname, err)
+	}
+}
+
+func TestReleaseVersion(t *testing.T) {
+	t.Parallel()
+
+	installDir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(installDir, "VERSION"), []byte("go1.22.3\ntime 2024-05-01T00:00:00Z\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"/tmp/go1.21.10.linux-amd64.tar.gz": "go1.21.10",
+		"go1.24rc1.darwin-arm64.tar.gz":     "go1.24rc1",
+		"go1.21.0.windows-amd64.zip":        "go1.21.0",
+		"/tmp/custom-go.tar.gz":             "go1.22.3",
+	}
+
+	for archivePath, want := range tests {
+		got, err := releaseVersion(archivePath, installDir)
+		if err != nil || got != want {
+			t.Errorf("releaseVersion(%q) = %q, %v, want %q", archivePath, got, err, want)
+		}
+	}
+}
+
+// TestMoveDirCrossDevice is not parallel because it replaces the package-level renameDir.
+func TestMoveDirCrossDevice(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "staging")
+	dst := filepath.Join(t.TempDir(), "go")
+
+	err := os.MkdirAll(filepath.Join(src, "bin"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Chmod(src, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(src, "bin", "go"), []byte("go binary"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	renameDir = func(string, string) error { return &os.LinkError{Op: "rename", Old: src, New: dst, Err: syscall.EXDEV} }
+
+	t.Cleanup(func() { renameDir = os.Rename })
+
+	err = moveDir(src, dst)
+	if err != nil {
+		t.Fatalf("moveDir() error = %v", err)
+	}
+
+	for path, want := range map[string]os.FileMode{dst: 0755, filepath.Join(dst, "bin"): 0755} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if info.Mode().Perm() != want {
+			t.Errorf("%s mode = %v, want %v", path, info.Mode().Perm(), want)
+		}
+	}
+
+	_, err = os.Stat(src)
+	if !os.IsNotExist(err) {
+		t.Errorf("source still exists after move, err = %v", err)
+	}
+}
+
+func TestCopyDir(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	realDir := filepath.Join(root, "go1.21.0")
+
+	err := os.MkdirAll(filepath.Join(realDir, "bin"), 0750)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(realDir, "bin", "go"), []byte("go binary"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := filepath.Join(root, "go")
+
+	err = os.Symlink(realDir, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(root, "backup")
+
+	err = CopyDir(src, dst)
+	if err != nil {
+		t.Fatalf("CopyDir() error = %v", err)
+	}
+
+	info, err := os.Lstat(filepath.Join(dst, "bin", "go"))
+	if err != nil || !info.Mode().IsRegular() {
+		t.Errorf("expected the resolved tree to be copied, got %v, %v", info, err)
+	}
+
+	err = CopyDir(src, filepath.Join(realDir, "backup"))
+	if !errors.Is(err, errDestinationInSource) {
+		t.Errorf("CopyDir() into the source error = %v, want %v", err, errDestinationInSource)
+	}
+}
+
+func TestVerifyPathResolution(t *testing.T) {
+	t.Parallel()
+
+	createGoBinary := func(t *testing.T) string {
+		t.Helper()
+
+		installDir := t.TempDir()
+		binDir := filepath.Join(installDir, "bin")
+
+		err := os.MkdirAll(binDir, 0750)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		//nolint:gosec // G306: executable permissions required for test binary
+		err = os.WriteFile(filepath.Join(binDir, "go"), nil, 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return installDir
+	}
+
+	installDir := createGoBinary(t)
+	otherDir := createGoBinary(t)
+	installBin := filepath.Join(installDir, "bin")
+	otherBin := filepath.Join(otherDir, "bin")
+	pathSeparator := string(os.PathListSeparator)
+
+	tests := []struct {
+		name    string
+		pathEnv string
+		wantErr error
+	}{
+		{name: "resolves to install", pathEnv: installBin + pathSeparator + otherBin, wantErr: nil},
+		{name: "shadowed", pathEnv: otherBin + pathSeparator + installBin, wantErr: ErrGoStillShadowed},
+		{name: "not on path", pathEnv: t.TempDir(), wantErr: ErrGoNotOnPath},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := verifyPathResolution(installDir, testCase.pathEnv)
+			if !errors.Is(err, testCase.wantErr) {
+				t.Errorf("verifyPathResolution() error = %v, want %v", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestEnsureSpace(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	available, err := AvailableSpace(dir)
+	if err != nil {
+		t.Fatalf("AvailableSpace() unexpected error: %v", err)
+	}
+
+	if available == 0 {
+		t.Skip("No free space reported for the temporary directory")
+	}
+
+	err = ensureSpace(dir, 1)
+	if err != nil {
+		t.Errorf("ensureSpace() unexpected error: %v", err)
+	}
+
+	err = ensureSpace(dir, math.MaxUint64)
+	if !errors.Is(err, ErrInsufficientSpace) {
+		t.Errorf("ensureSpace() error = %v, want %v", err, ErrInsufficientSpace)
+	}
+}
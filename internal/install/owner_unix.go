@@ -0,0 +1,75 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+//go:build !windows
+
+package install
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"syscall"
+)
+
+// InvokingOwner returns the uid and gid of the user who ran goUpdater through sudo or doas, provided
+// that user owns the directory installDir is created in, such as ~/sdk for ~/sdk/go. ok is false when
+// goUpdater is not running as root on another user's behalf, or when installDir is not under a
+// directory that user owns, as for /usr/local/go; the installed tree is then left owned by root.
+func InvokingOwner(installDir string) (uid, gid int, ok bool) {
+	if os.Geteuid() != 0 {
+		return 0, 0, false
+	}
+
+	uid, gid, ok = invokingUser()
+	if !ok || uid == 0 {
+		return 0, 0, false
+	}
+
+	return uid, gid, ownedBy(filepath.Dir(filepath.Clean(installDir)), uid)
+}
+
+// invokingUser returns the uid and gid of the user named by SUDO_UID and SUDO_GID, or by DOAS_USER.
+func invokingUser() (uid, gid int, ok bool) {
+	uidStr, gidStr := os.Getenv("SUDO_UID"), os.Getenv("SUDO_GID")
+
+	if uidStr == "" {
+		invoker, err := user.Lookup(os.Getenv("DOAS_USER"))
+		if err != nil {
+			return 0, 0, false
+		}
+
+		uidStr, gidStr = invoker.Uid, invoker.Gid
+	}
+
+	uid, uidErr := strconv.Atoi(uidStr)
+	gid, gidErr := strconv.Atoi(gidStr)
+
+	if uidErr != nil || gidErr != nil {
+		return 0, 0, false
+	}
+
+	return uid, gid, true
+}
+
+// ownedBy reports whether dir, or its nearest existing ancestor when dir has not been created yet, is owned by uid.
+func ownedBy(dir string, uid int) bool {
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			stat, ok := info.Sys().(*syscall.Stat_t)
+
+			return ok && int(stat.Uid) == uid
+		}
+
+		parent := filepath.Dir(dir)
+		if !errors.Is(err, fs.ErrNotExist) || parent == dir {
+			return false
+		}
+
+		dir = parent
+	}
+}
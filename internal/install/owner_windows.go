@@ -0,0 +1,12 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+//go:build windows
+
+package install
+
+// InvokingOwner always reports false on Windows, where elevation does not run on another user's behalf
+// and file ownership is not changed.
+func InvokingOwner(string) (uid, gid int, ok bool) {
+	return 0, 0, false
+}
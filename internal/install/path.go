@@ -0,0 +1,81 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package install
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/nicholas-fedor/goUpdater/internal/logger"
+)
+
+// PathGuidance returns instructions for adding installDir's bin directory to PATH, or an empty string
+// when pathList, a PATH value, already contains it. shell is the user's shell, as in $SHELL, and
+// selects the syntax and startup file of the suggested line; unknown shells get POSIX sh syntax.
+func PathGuidance(installDir, pathList, shell string) string {
+	binDir := filepath.Clean(filepath.Join(installDir, "bin"))
+
+	for _, entry := range filepath.SplitList(pathList) {
+		if entry != "" && samePath(filepath.Clean(entry), binDir) {
+			return ""
+		}
+	}
+
+	switch strings.TrimSuffix(filepath.Base(shell), ".exe") {
+	case "fish":
+		return fmt.Sprintf("%s is not on your PATH. To add it, run:\n    fish_add_path %s", binDir, binDir)
+	case "powershell", "pwsh":
+		return fmt.Sprintf("%s is not on your PATH. To add it, run:\n"+
+			"    [Environment]::SetEnvironmentVariable(\"Path\", $env:Path + \";%s\", \"User\")", binDir, binDir)
+	case "zsh":
+		return exportGuidance(binDir, "~/.zshrc")
+	case "bash":
+		return exportGuidance(binDir, "~/.bashrc")
+	default:
+		return exportGuidance(binDir, "~/.profile")
+	}
+}
+
+// exportGuidance returns POSIX sh instructions for adding binDir to PATH in startupFile.
+func exportGuidance(binDir, startupFile string) string {
+	return fmt.Sprintf("%s is not on your PATH. To add it, append this line to %s and open a new shell:\n"+
+		"    export PATH=\"$PATH:%s\"", binDir, startupFile, binDir)
+}
+
+// samePath reports whether two cleaned paths name the same directory, ignoring case on Windows.
+func samePath(first, second string) bool {
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(first, second)
+	}
+
+	return first == second
+}
+
+// LogPathGuidance logs PathGuidance for installDir using the current PATH and shell.
+// When goUpdater runs as root on behalf of another user through sudo or doas, PATH is the one set by
+// the elevation tool rather than the user's, so the guidance is only logged after a fresh install,
+// where it is most likely needed.
+func LogPathGuidance(installDir string, freshInstall bool) {
+	elevated := os.Getenv("SUDO_USER") != "" || os.Getenv("DOAS_USER") != ""
+	if elevated && !freshInstall {
+		return
+	}
+
+	shell := os.Getenv("SHELL")
+	if shell == "" && runtime.GOOS == "windows" {
+		shell = "powershell"
+	}
+
+	guidance := PathGuidance(installDir, os.Getenv("PATH"), shell)
+	if guidance == "" {
+		return
+	}
+
+	for line := range strings.SplitSeq(guidance, "\n") {
+		logger.Info(line)
+	}
+}
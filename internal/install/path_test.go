@@ -0,0 +1,57 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package install
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPathGuidance(t *testing.T) {
+	t.Parallel()
+
+	installDir := filepath.Join(string(filepath.Separator)+"usr", "local", "go")
+	binDir := filepath.Join(installDir, "bin")
+	otherDir := filepath.Join(string(filepath.Separator)+"usr", "bin")
+
+	tests := []struct {
+		name     string
+		pathList string
+		shell    string
+		want     string
+	}{
+		{
+			name:     "already on PATH",
+			pathList: strings.Join([]string{otherDir, binDir + string(filepath.Separator)}, string(filepath.ListSeparator)),
+			shell:    "/bin/bash",
+			want:     "",
+		},
+		{name: "bash", pathList: otherDir, shell: "/bin/bash", want: "~/.bashrc"},
+		{name: "zsh", pathList: otherDir, shell: "/usr/bin/zsh", want: "~/.zshrc"},
+		{name: "fish", pathList: otherDir, shell: "/usr/bin/fish", want: "fish_add_path " + binDir},
+		{name: "powershell", pathList: otherDir, shell: "pwsh", want: "SetEnvironmentVariable"},
+		{name: "unknown shell", pathList: "", shell: "", want: "export PATH=\"$PATH:" + binDir + "\""},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			guidance := PathGuidance(installDir, testCase.pathList, testCase.shell)
+
+			if testCase.want == "" {
+				if guidance != "" {
+					t.Errorf("PathGuidance() = %q, want no guidance", guidance)
+				}
+
+				return
+			}
+
+			if !strings.Contains(guidance, testCase.want) {
+				t.Errorf("PathGuidance() = %q, want it to contain %q", guidance, testCase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+//go:build !windows
+
+package install
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// AvailableSpace returns the number of bytes available to unprivileged users on the filesystem containing path.
+func AvailableSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+
+	err := syscall.Statfs(path, &stat)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat filesystem of %s: %w", path, err)
+	}
+
+	// The field types differ between platforms, so both are converted explicitly.
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil //nolint:gosec,unconvert
+}
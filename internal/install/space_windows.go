@@ -0,0 +1,29 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+//go:build windows
+
+package install
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows"
+)
+
+// AvailableSpace returns the number of bytes available to the current user on the volume containing path.
+func AvailableSpace(path string) (uint64, error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, fmt.Errorf("invalid path %s: %w", path, err)
+	}
+
+	var available uint64
+
+	err = windows.GetDiskFreeSpaceEx(pathPtr, &available, nil, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query free space of %s: %w", path, err)
+	}
+
+	return available, nil
+}
@@ -0,0 +1,139 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package install
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/nicholas-fedor/goUpdater/internal/download"
+	"github.com/nicholas-fedor/goUpdater/internal/logger"
+	"github.com/nicholas-fedor/goUpdater/internal/verify"
+)
+
+// CurrentLink is the name of the symlink in a versioned base directory that points at the active Go version.
+const CurrentLink = "current"
+
+// ErrVersionNotInstalled indicates that Use was asked to switch to a version missing from the base directory.
+var ErrVersionNotInstalled = errors.New("go version is not installed")
+
+// ErrInvalidVersion indicates a version that does not name a Go release, such as one containing a path.
+var ErrInvalidVersion = errors.New("invalid Go version")
+
+// Versioned installs a published Go version into its own directory below baseDir, such as ~/sdk/go1.22.0,
+// leaving the other versions there in place, and makes it the active version with Use. An empty version
+// installs the latest stable release. A version that is already installed is not downloaded again.
+// It returns the directory of the installed version; baseDir/current points at it. A version from the
+// release feed that does not name a Go release is refused with ErrInvalidVersion before anything is written.
+func Versioned(baseDir, version string, excludes []string) (string, error) {
+	var (
+		info *download.GoVersionInfo
+		err  error
+	)
+
+	if version == "" {
+		info, err = download.GetLatestVersionInfo()
+	} else {
+		info, err = download.GetVersionInfo(version)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the Go version to install: %w", err)
+	}
+
+	// The version comes from the remote release feed, so it must not be able to reach outside baseDir.
+	if !strings.HasPrefix(info.Version, "go") || !download.IsGoVersion(info.Version) {
+		return "", fmt.Errorf("release feed returned %q: %w", info.Version, ErrInvalidVersion)
+	}
+
+	versionDir := filepath.Join(baseDir, info.Version)
+
+	_, err = verify.GetInstalledVersion(versionDir)
+	if err == nil {
+		logger.Infof("Go %s is already installed in %s", info.Version, versionDir)
+	} else {
+		err = installVersionDir(info.Version, versionDir, excludes)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = Use(baseDir, info.Version)
+	if err != nil {
+		return "", err
+	}
+
+	return versionDir, nil
+}
+
+// installVersionDir downloads the archive of the published Go version and installs it into versionDir.
+func installVersionDir(version, versionDir string, excludes []string) error {
+	tempDir, err := download.MkdirTemp(download.TempDirPrefix + "install-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	archivePath, _, err := download.Get(version, tempDir)
+	if err != nil {
+		return fmt.Errorf("failed to download Go %s: %w", version, err)
+	}
+
+	return goWithVerification(archivePath, versionDir, excludes)
+}
+
+// Use makes an installed Go version below baseDir the active one by pointing baseDir/current at it.
+// The version may be given with or without the "go" prefix. The symlink is replaced atomically, so
+// baseDir/current always resolves to a complete installation. It returns ErrInvalidVersion for anything
+// but a Go release name, so the version cannot reach outside baseDir, and ErrVersionNotInstalled when
+// baseDir holds no such version.
+func Use(baseDir, version string) error {
+	if !download.IsGoVersion(version) {
+		return fmt.Errorf("%q: %w", version, ErrInvalidVersion)
+	}
+
+	name := "go" + strings.TrimPrefix(version, "go")
+	versionDir := filepath.Join(baseDir, name)
+
+	_, err := verify.GetInstalledVersion(versionDir)
+	if err != nil {
+		return fmt.Errorf("%s in %s: %w", name, baseDir, ErrVersionNotInstalled)
+	}
+
+	err = replaceSymlink(name, filepath.Join(baseDir, CurrentLink))
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Switched %s to Go %s", filepath.Join(baseDir, CurrentLink), name)
+
+	return nil
+}
+
+// replaceSymlink points linkPath at target, replacing any existing symlink there in a single rename.
+// A relative target is resolved from the directory of linkPath, so the base directory can be moved.
+func replaceSymlink(target, linkPath string) error {
+	tempLink := filepath.Join(filepath.Dir(linkPath), "."+filepath.Base(linkPath)+".tmp-"+strconv.Itoa(os.Getpid()))
+
+	_ = os.Remove(tempLink)
+
+	err := os.Symlink(target, tempLink)
+	if err != nil {
+		return fmt.Errorf("failed to create symlink to %s: %w", target, err)
+	}
+
+	err = os.Rename(tempLink, linkPath)
+	if err != nil {
+		_ = os.Remove(tempLink)
+
+		return fmt.Errorf("failed to point %s at %s: %w", linkPath, target, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package install
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestUse(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake go binary is a shell script")
+	}
+
+	baseDir := t.TempDir()
+
+	for _, version := range []string{"go1.21.0", "go1.22.0"} {
+		err := os.MkdirAll(filepath.Join(baseDir, version, "bin"), 0750)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		script := "#!/bin/sh\necho \"go version " + version + " linux/amd64\"\n"
+
+		//nolint:gosec // G306: executable permissions required for test binary
+		err = os.WriteFile(filepath.Join(baseDir, version, "bin", "go"), []byte(script), 0700)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	linkPath := filepath.Join(baseDir, CurrentLink)
+
+	for _, version := range []string{"go1.21.0", "1.22.0"} {
+		err := Use(baseDir, version)
+		if err != nil {
+			t.Fatalf("Use(%q) unexpected error: %v", version, err)
+		}
+	}
+
+	target, err := os.Readlink(linkPath)
+	if err != nil || target != "go1.22.0" {
+		t.Errorf("%s -> %q (err %v), want go1.22.0", CurrentLink, target, err)
+	}
+
+	err = Use(baseDir, "go1.23.0")
+	if !errors.Is(err, ErrVersionNotInstalled) {
+		t.Errorf("Use() error = %v, want %v", err, ErrVersionNotInstalled)
+	}
+
+	for _, version := range []string{"/../../opt/x", "../go1.21.0", "go1.21.0/.."} {
+		err = Use(baseDir, version)
+		if !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("Use(%q) error = %v, want %v", version, err, ErrInvalidVersion)
+		}
+	}
+
+	target, err = os.Readlink(linkPath)
+	if err != nil || target != "go1.22.0" {
+		t.Errorf("%s -> %q (err %v) after a failed switch, want go1.22.0", CurrentLink, target, err)
+	}
+}
+
+func TestVersionedRejectsInvalidFeedVersion(t *testing.T) {
+	// Subtests use t.Setenv() which cannot be used with parallel tests
+	for _, version := range []string{"../../etc", "go1.22.0/../../x", "1.22.0", ""} {
+		t.Run(version, func(t *testing.T) {
+			feed := fmt.Sprintf(`[{"version": %q, "stable": true, "files": []}]`, version)
+
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+				_, _ = writer.Write([]byte(feed))
+			}))
+			t.Cleanup(server.Close)
+
+			t.Setenv("GO_UPDATER_BASE_URL", server.URL)
+
+			baseDir := t.TempDir()
+
+			_, err := Versioned(baseDir, "", nil)
+			if !errors.Is(err, ErrInvalidVersion) {
+				t.Errorf("Versioned() error = %v, want %v", err, ErrInvalidVersion)
+			}
+
+			entries, err := os.ReadDir(baseDir)
+			if err != nil || len(entries) != 0 {
+				t.Errorf("base directory holds %d entries (err %v), want none", len(entries), err)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ package logger
 
 import (
 	"io"
+	"os"
 	"strings"
 
 	"github.com/rs/zerolog"
@@ -125,6 +126,30 @@ func SetVerbose(v bool) {
 	}
 }
 
+// SetMachineReadable reserves stdout for a command's machine-readable output.
+// When enabled, log lines are written to stderr and informational messages are dropped
+// unless verbose logging is enabled, leaving only warnings and errors.
+// Call it after SetVerbose.
+func SetMachineReadable(enabled bool) {
+	if !enabled {
+		return
+	}
+
+	SetWriter(os.Stderr)
+
+	if !verbose {
+		zerolog.SetGlobalLevel(zerolog.WarnLevel)
+	}
+}
+
+// SetQuiet drops every message below the error level when enabled.
+// Call it after SetVerbose and SetMachineReadable so that it takes precedence.
+func SetQuiet(enabled bool) {
+	if enabled {
+		zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	}
+}
+
 // SetWriter sets the output writer for the logger.
 // This is primarily used for testing to capture log output.
 func SetWriter(w io.Writer) {
@@ -5,6 +5,7 @@ package logger
 
 import (
 	"bytes"
+	"os"
 	"strings"
 	"testing"
 )
@@ -200,3 +201,58 @@ func TestInfoVerbose(t *testing.T) {
 	// Reset to default
 	SetVerbose(false)
 }
+
+// TestSetQuiet is not parallel because it changes the global log level and writer.
+func TestSetQuiet(t *testing.T) {
+	SetVerbose(true)
+	SetQuiet(true)
+
+	t.Cleanup(func() {
+		SetVerbose(false)
+		SetWriter(os.Stdout)
+	})
+
+	var buf bytes.Buffer
+	SetWriter(&buf)
+
+	Debug("debug message")
+	Warn("warning message")
+	Error("error message")
+
+	output := buf.String()
+
+	if strings.Contains(output, "debug message") || strings.Contains(output, "warning message") {
+		t.Errorf("Expected messages below the error level to be dropped, got %s", output)
+	}
+
+	if !strings.Contains(output, "error message") {
+		t.Errorf("Expected errors to be logged, got %s", output)
+	}
+}
+
+// TestSetMachineReadable is not parallel because it changes the global log level and writer.
+func TestSetMachineReadable(t *testing.T) {
+	SetVerbose(false)
+	SetMachineReadable(true)
+
+	t.Cleanup(func() {
+		SetVerbose(false)
+		SetWriter(os.Stdout)
+	})
+
+	var buf bytes.Buffer
+	SetWriter(&buf)
+
+	Info("informational message")
+	Warn("warning message")
+
+	output := buf.String()
+
+	if strings.Contains(output, "informational message") {
+		t.Errorf("Expected informational messages to be dropped, got %s", output)
+	}
+
+	if !strings.Contains(output, "warning message") {
+		t.Errorf("Expected warnings to be logged, got %s", output)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package privileges
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nicholas-fedor/goUpdater/internal/logger"
+)
+
+// auditLogPerm is the permission used when creating the audit log.
+const auditLogPerm = 0600
+
+// Audited privilege operations.
+const (
+	auditOpElevationAttempt    = "elevation-attempt"    // About to re-execute through the elevation tool
+	auditOpElevationRequest    = "elevation-request"    // Elevation refused or failed before re-executing
+	auditOpPrivilegedOperation = "privileged-operation" // Running an operation with elevated privileges
+)
+
+// auditLog is the file that audit records are appended to, set by SetAuditLog.
+//
+//nolint:gochecknoglobals
+var (
+	auditMutex sync.Mutex
+	auditLog   *os.File
+)
+
+// AuditRecord is a single line of the audit log.
+type AuditRecord struct {
+	Time    time.Time `json:"time"`
+	Op      string    `json:"op"`
+	Success bool      `json:"success"`
+	UID     int       `json:"uid"`
+	Target  string    `json:"target"`
+	Reason  string    `json:"reason"`
+}
+
+// SetAuditLog appends a JSON line for every elevation request and privileged operation to the file at path,
+// creating it if needed. The elevated process reopens the same path, so both sides of an elevation are recorded.
+// An empty path closes any open audit log and disables auditing.
+func SetAuditLog(path string) error {
+	auditMutex.Lock()
+	defer auditMutex.Unlock()
+
+	if auditLog != nil {
+		_ = auditLog.Close()
+		auditLog = nil
+	}
+
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, auditLogPerm) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	auditLog = file
+
+	return nil
+}
+
+// audit appends a record to the audit log, if one is set.
+// Failures to write are logged rather than returned so that auditing never changes the outcome of an operation.
+func audit(op, target string, err error) {
+	auditWithReason(op, target, "", err)
+}
+
+// auditWithReason behaves like audit, recording reason, such as the elevation tool used, in the record.
+// The error of a failed operation follows it.
+func auditWithReason(op, target, reason string, err error) {
+	auditMutex.Lock()
+	defer auditMutex.Unlock()
+
+	if auditLog == nil {
+		return
+	}
+
+	record := AuditRecord{
+		Time:    time.Now().UTC(),
+		Op:      op,
+		Success: err == nil,
+		UID:     os.Getuid(),
+		Target:  target,
+		Reason:  reason,
+	}
+
+	switch {
+	case err != nil && reason != "":
+		record.Reason = reason + ": " + err.Error()
+	case err != nil:
+		record.Reason = err.Error()
+	}
+
+	encodeErr := json.NewEncoder(auditLog).Encode(record)
+	if encodeErr != nil {
+		logger.Warnf("Failed to write audit record: %v", encodeErr)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package privileges
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuditLog(t *testing.T) {
+	// Not parallel: the test replaces the global audit log
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	err := SetAuditLog(path)
+	if err != nil {
+		t.Fatalf("SetAuditLog() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = SetAuditLog("") })
+
+	audit(auditOpElevationRequest, "/usr/local/bin/goUpdater", nil)
+	audit(auditOpPrivilegedOperation, "/usr/local/go", errCallback)
+
+	err = SetAuditLog("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Records are no longer written once auditing is disabled
+	audit(auditOpPrivilegedOperation, "/usr/local/go", nil)
+
+	records := readAuditRecords(t, path)
+
+	if len(records) != 2 {
+		t.Fatalf("got %d audit records, want 2", len(records))
+	}
+
+	if records[0].Op != auditOpElevationRequest || !records[0].Success || records[0].UID != os.Getuid() {
+		t.Errorf("unexpected first record: %+v", records[0])
+	}
+
+	if records[1].Success || records[1].Target != "/usr/local/go" || records[1].Reason != errCallback.Error() {
+		t.Errorf("unexpected second record: %+v", records[1])
+	}
+}
+
+func TestElevateAudit(t *testing.T) {
+	// Not parallel: the test replaces the global audit log
+	errExec := errors.New("exec failed")
+
+	tests := []struct {
+		name    string
+		execErr error
+		wantOps []string
+	}{
+		{name: "exec succeeds", execErr: nil, wantOps: []string{auditOpElevationAttempt}},
+		{
+			name:    "exec fails",
+			execErr: errExec,
+			wantOps: []string{auditOpElevationAttempt, auditOpElevationRequest},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+			err := SetAuditLog(path)
+			if err != nil {
+				t.Fatalf("SetAuditLog() error = %v", err)
+			}
+
+			t.Cleanup(func() { _ = SetAuditLog("") })
+
+			err = elevate("/usr/bin/doas", "/usr/local/bin/goUpdater", []string{"update"},
+				func(string, string, []string) error { return testCase.execErr })
+			if !errors.Is(err, testCase.execErr) {
+				t.Fatalf("elevate() error = %v, want %v", err, testCase.execErr)
+			}
+
+			records := readAuditRecords(t, path)
+			if len(records) != len(testCase.wantOps) {
+				t.Fatalf("got %d audit records, want %d: %+v", len(records), len(testCase.wantOps), records)
+			}
+
+			for i, record := range records {
+				if record.Op != testCase.wantOps[i] {
+					t.Errorf("record %d op = %q, want %q", i, record.Op, testCase.wantOps[i])
+				}
+
+				if !strings.HasPrefix(record.Reason, "tool doas") {
+					t.Errorf("record %d reason = %q, want it to name the elevation tool", i, record.Reason)
+				}
+			}
+
+			// Only the attempt record reports success; a failure is never preceded by a success record.
+			last := records[len(records)-1]
+			if last.Success != (testCase.execErr == nil) {
+				t.Errorf("final record success = %v, want %v", last.Success, testCase.execErr == nil)
+			}
+		})
+	}
+}
+
+// readAuditRecords decodes every JSON line of the audit log at path.
+func readAuditRecords(t *testing.T, path string) []AuditRecord {
+	t.Helper()
+
+	file, err := os.Open(path) // #nosec G304 -- test file in a temporary directory
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	var records []AuditRecord
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record AuditRecord
+
+		err = json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			t.Fatalf("invalid audit record %q: %v", scanner.Text(), err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
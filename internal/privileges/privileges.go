@@ -1,25 +1,249 @@
 // Copyright © 2025 Nicholas Fedor
 // SPDX-License-Identifier: AGPL-3.0-or-later
 
-// Package privileges provides functions to detect privileges and request elevation using sudo.
+// Package privileges provides functions to detect privileges and request elevation using sudo, doas, or pkexec,
+// or a UAC prompt on Windows.
 // It handles privilege escalation for system operations that require root access.
 package privileges
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"syscall"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/nicholas-fedor/goUpdater/internal/logger"
 )
 
+const (
+	defaultSudoPath = "/usr/bin/sudo"            // Default location of the sudo binary
+	sudoPathEnvVar  = "GOUPDATER_SUDO_PATH"      // Environment variable overriding the sudo binary path
+	toolEnvVar      = "GOUPDATER_ELEVATION_TOOL" // Environment variable selecting sudo, doas, or pkexec
+	noElevateEnvVar = "GO_UPDATER_NO_ELEVATE"    // Environment variable that, when "1" or "true", forbids elevation
+	executableMask  = 0111                       // Permission bits indicating an executable file
+)
+
+// ErrUnknownElevationTool indicates an elevation tool other than sudo, doas, or pkexec was requested.
+var ErrUnknownElevationTool = errors.New("unknown elevation tool; supported tools are sudo, doas, and pkexec")
+
+// ErrElevationRequired indicates an operation needed elevated privileges while elevation is disabled,
+// either with SetNoElevate or GO_UPDATER_NO_ELEVATE.
+var ErrElevationRequired = errors.New("elevated privileges are required but elevation is disabled; rerun as root")
+
+// ErrArgNotAllowed indicates a command-line flag outside the allowlist set with SetAllowedArgs.
+var ErrArgNotAllowed = errors.New("argument not allowed for elevated re-execution")
+
+// errUnsafeOwnership indicates the elevation binary could have been replaced by an untrusted user.
+var errUnsafeOwnership = errors.New("unsafe ownership or permissions")
+
+// errNotExecutable indicates the elevation binary is not an executable regular file.
+var errNotExecutable = errors.New("not an executable file")
+
+// sudoPathMutex protects sudoPathFlag, elevationToolFlag, and noElevateFlag.
+//
+//nolint:gochecknoglobals
+var (
+	sudoPathMutex     sync.Mutex
+	sudoPathFlag      string
+	elevationToolFlag string
+	noElevateFlag     bool
+)
+
+// allowedArgs holds the flags permitted when re-executing with elevated privileges; nil allows all.
+// forwardedArgs holds the arguments appended to the elevated re-execution, set by SetForwardedArgs.
+//
+//nolint:gochecknoglobals
+var (
+	allowedArgsMutex sync.Mutex
+	allowedArgs      []string
+	forwardedArgs    []string
+)
+
+// elevationFlags lists the flags that choose how to elevate. They only matter to the process requesting
+// elevation, so elevate drops them, with their values, from the command line of the elevated process.
+//
+//nolint:gochecknoglobals
+var elevationFlags = []string{"--sudo-path", "--elevation-tool", "--no-elevate"}
+
+// defaultElevationPaths lists the elevation binaries tried, in order, when no path is configured.
+// sudo is preferred; doas is used on systems that only ship doas, such as OpenBSD.
+//
+//nolint:gochecknoglobals
+var defaultElevationPaths = []string{defaultSudoPath, "/usr/bin/doas", "/usr/local/bin/doas"}
+
+// elevationToolPaths lists where each selectable elevation tool is looked for.
+// pkexec prompts through the desktop's polkit agent, so it works without a terminal.
+//
+//nolint:gochecknoglobals
+var elevationToolPaths = map[string][]string{
+	"sudo":   {defaultSudoPath},
+	"doas":   {"/usr/bin/doas", "/usr/local/bin/doas"},
+	"pkexec": {"/usr/bin/pkexec"},
+}
+
+// ElevationError describes a failure to prepare or perform privilege elevation.
+// It records the elevation binary path involved and wraps the underlying cause.
+type ElevationError struct {
+	Path   string
+	Reason string
+	Err    error
+}
+
+// Error returns a human-readable description of the elevation failure.
+func (e *ElevationError) Error() string {
+	return fmt.Sprintf("elevation via %s failed: %s: %v", e.Path, e.Reason, e.Err)
+}
+
+// Unwrap returns the underlying cause of the elevation failure.
+func (e *ElevationError) Unwrap() error {
+	return e.Err
+}
+
+// SetSudoPath sets an explicit sudo binary path, typically from the --sudo-path flag.
+// An empty path clears the override so GOUPDATER_SUDO_PATH or the default is used.
+func SetSudoPath(path string) {
+	sudoPathMutex.Lock()
+
+	sudoPathFlag = path
+
+	sudoPathMutex.Unlock()
+}
+
+// SetElevationTool selects the elevation tool by name: "sudo", "doas", or "pkexec", typically from the
+// --elevation-tool flag. An empty name clears the selection so GOUPDATER_ELEVATION_TOOL or automatic
+// detection (sudo, then doas) is used. An explicit --sudo-path or GOUPDATER_SUDO_PATH takes precedence.
+func SetElevationTool(name string) error {
+	if name != "" && elevationToolPaths[name] == nil {
+		return fmt.Errorf("%q: %w", name, ErrUnknownElevationTool)
+	}
+
+	sudoPathMutex.Lock()
+
+	elevationToolFlag = name
+
+	sudoPathMutex.Unlock()
+
+	return nil
+}
+
+// SetNoElevate disables elevation, typically from the --no-elevate flag, so that operations needing
+// elevated privileges fail with ErrElevationRequired instead of prompting. This suits unattended runs,
+// which would otherwise hang on a password prompt. Setting GO_UPDATER_NO_ELEVATE=1 has the same effect.
+func SetNoElevate(disabled bool) {
+	sudoPathMutex.Lock()
+
+	noElevateFlag = disabled
+
+	sudoPathMutex.Unlock()
+}
+
+// elevationDisabled reports whether elevation was disabled by SetNoElevate or the environment.
+func elevationDisabled() bool {
+	sudoPathMutex.Lock()
+	disabled := noElevateFlag
+	sudoPathMutex.Unlock()
+
+	if disabled {
+		return true
+	}
+
+	disabled, _ = strconv.ParseBool(os.Getenv(noElevateEnvVar))
+
+	return disabled
+}
+
+// SetAllowedArgs restricts the flags that may be passed to the elevated process to the given names,
+// such as "--install-dir" or "-d". Flag values, subcommands, and other positional arguments are not
+// restricted. A nil or empty list removes the restriction.
+func SetAllowedArgs(flags []string) {
+	allowedArgsMutex.Lock()
+
+	allowedArgs = slices.Clone(flags)
+
+	allowedArgsMutex.Unlock()
+}
+
+// SetForwardedArgs sets arguments, such as "--tmpdir=/var/tmp", appended to the command line of the
+// elevated process. sudo and doas reset the environment by default, so settings held in environment
+// variables are forwarded this way instead. A nil or empty list forwards nothing.
+func SetForwardedArgs(args []string) {
+	allowedArgsMutex.Lock()
+
+	forwardedArgs = slices.Clone(args)
+
+	allowedArgsMutex.Unlock()
+}
+
+// validateArgs checks every flag in args against allowed, returning an ElevationError for the first flag
+// that is not listed. A single-dash argument is checked by its first shorthand, so "-d/opt/go" is "-d".
+// Arguments after a "--" terminator are positional and not checked.
+func validateArgs(args, allowed []string) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	for _, arg := range args {
+		if arg == "--" {
+			return nil
+		}
+
+		if arg == "-" || !strings.HasPrefix(arg, "-") {
+			continue
+		}
+
+		name, _, _ := strings.Cut(arg, "=")
+		if !strings.HasPrefix(arg, "--") {
+			name = arg[:2]
+		}
+
+		if !slices.Contains(allowed, name) {
+			return &ElevationError{Path: arg, Reason: "flag is not on the allowlist", Err: ErrArgNotAllowed}
+		}
+	}
+
+	return nil
+}
+
+// stripElevationFlags returns args without the elevationFlags and their values, given either after "=" or
+// as the next argument. --no-elevate is a boolean flag, so it never takes the next argument.
+// Arguments after a "--" terminator are positional and kept.
+func stripElevationFlags(args []string) []string {
+	stripped := make([]string, 0, len(args))
+
+	for index := 0; index < len(args); index++ {
+		arg := args[index]
+		if arg == "--" {
+			return append(stripped, args[index:]...)
+		}
+
+		name, _, hasValue := strings.Cut(arg, "=")
+		if !slices.Contains(elevationFlags, name) {
+			stripped = append(stripped, arg)
+
+			continue
+		}
+
+		if !hasValue && name != "--no-elevate" {
+			index++
+		}
+	}
+
+	return stripped
+}
+
 // IsRoot reports whether the current process is running as root.
+// On Windows, it reports whether the process holds an elevated (administrator) token.
 func IsRoot() bool {
-	return os.Geteuid() == 0
+	return isRoot()
 }
 
-// RequestElevation re-executes the current process with sudo if not already running as root.
+// RequestElevation re-executes the current process with sudo, or doas where sudo is absent,
+// if not already running as root. pkexec can be selected with SetElevationTool.
+// On Windows, the process is relaunched through a UAC prompt instead.
 func RequestElevation() error {
 	logger.Debug("Checking if elevation is needed")
 	// Check if already running as root
@@ -29,7 +253,7 @@ func RequestElevation() error {
 		return nil
 	}
 
-	logger.Debug("Requesting elevation via sudo")
+	logger.Debug("Requesting elevation")
 
 	// Get the path to the current executable
 	exePath, err := os.Executable()
@@ -47,22 +271,49 @@ func RequestElevation() error {
 
 	logger.Debugf("Resolved executable path: %s", exePath)
 
-	// Prepare the command arguments: sudo followed by the executable and original args
-	args := append([]string{"sudo", exePath}, os.Args[1:]...)
-	logger.Debugf("Sudo command args: %v", args)
+	toolPath, err := elevationTool()
+	if err != nil {
+		audit(auditOpElevationRequest, exePath, err)
+
+		return err
+	}
+
+	return elevate(toolPath, exePath, os.Args[1:], execElevated)
+}
+
+// elevate drops the elevationFlags from args, checks the rest against the allowlist, and re-executes
+// exePath through run with the elevation binary at toolPath, passing them followed by the forwarded
+// arguments. An elevation-attempt record naming the tool is written just before run, since a successful
+// exec never returns to record anything, and an elevation-request failure record is written only when
+// validation or run fails.
+func elevate(
+	toolPath, exePath string,
+	args []string,
+	run func(toolPath, exePath string, args []string) error,
+) error {
+	allowedArgsMutex.Lock()
+	allowed, forwarded := allowedArgs, forwardedArgs
+	allowedArgsMutex.Unlock()
 
-	// Use syscall.Exec to replace the current process entirely with sudo
-	// This is necessary for sudo to work properly and maintain the process environment
-	// gosec: G204 - Subprocess launched with variable is acceptable here as we control the args
-	logger.Debug("Executing with sudo")
+	args = stripElevationFlags(args)
 
-	err = syscall.Exec("/usr/bin/sudo", args, os.Environ()) //nolint:gosec
+	err := validateArgs(args, allowed)
 	if err != nil {
-		return fmt.Errorf("failed to execute with sudo: %w", err)
+		audit(auditOpElevationRequest, exePath, err)
+
+		return err
 	}
 
-	// This point should never be reached if syscall.Exec succeeds
-	return nil
+	tool := "tool " + filepath.Base(toolPath)
+
+	auditWithReason(auditOpElevationAttempt, exePath, tool, nil)
+
+	err = run(toolPath, exePath, append(slices.Clone(args), forwarded...))
+	if err != nil {
+		auditWithReason(auditOpElevationRequest, exePath, tool, err)
+	}
+
+	return err
 }
 
 // HandleElevationError logs and exits with an error message for privilege elevation failures.
@@ -80,6 +331,13 @@ func HandleElevationError(err error) {
 func ElevateAndExecute(callback func() error) error {
 	logger.Debug("Checking privileges for operation")
 
+	if !IsRoot() && elevationDisabled() {
+		logger.Debug("Not running as root and elevation is disabled")
+		audit(auditOpElevationRequest, "", ErrElevationRequired)
+
+		return ErrElevationRequired
+	}
+
 	if !IsRoot() {
 		logger.Debug("Not running as root, requesting elevation")
 
@@ -89,7 +347,7 @@ func ElevateAndExecute(callback func() error) error {
 			// HandleElevationError exits, so this point is not reached
 		}
 		// If RequestElevation succeeds, the process is re-executed with elevation
-		logger.Debug("Elevation request successful, process re-executed with sudo")
+		logger.Debug("Elevation request successful, process re-executed with elevated privileges")
 
 		return nil
 	}
@@ -103,10 +361,182 @@ func ElevateAndExecute(callback func() error) error {
 		logger.Errorf("Error executing privileged operation: %v", err)
 	}
 
+	audit(auditOpPrivilegedOperation, "", err)
+
 	return err
 }
 
+// RequiresElevation reports whether writing to targetPath requires elevated privileges.
+// It probes the target directory, if it exists, and the nearest existing ancestor by creating
+// and removing a temporary file. Running as root never requires elevation.
+func RequiresElevation(targetPath string) bool {
+	if IsRoot() {
+		return false
+	}
+
+	cleanPath := filepath.Clean(targetPath)
+
+	info, err := os.Stat(cleanPath)
+	if err == nil && info.IsDir() && !isWritableDir(cleanPath) {
+		logger.Debugf("Target directory is not writable: %s", cleanPath)
+
+		return true
+	}
+
+	parent := filepath.Dir(cleanPath)
+	for {
+		_, err = os.Stat(parent)
+		if err == nil || filepath.Dir(parent) == parent {
+			break
+		}
+
+		parent = filepath.Dir(parent)
+	}
+
+	if !isWritableDir(parent) {
+		logger.Debugf("Parent directory is not writable: %s", parent)
+
+		return true
+	}
+
+	logger.Debugf("Target is writable without elevation: %s", cleanPath)
+
+	return false
+}
+
+// ElevateIfRequired runs the callback directly when targetPath is writable by the current user,
+// and otherwise delegates to ElevateAndExecute to obtain elevated privileges first.
+// This avoids gratuitous sudo prompts for user-local installations such as ~/.local/go.
+func ElevateIfRequired(targetPath string, callback func() error) error {
+	if RequiresElevation(targetPath) {
+		return ElevateAndExecute(callback)
+	}
+
+	logger.Debug("Elevation not required, executing operation directly")
+
+	err := callback()
+	if err != nil {
+		logger.Errorf("Error executing operation: %v", err)
+	}
+
+	if IsRoot() {
+		audit(auditOpPrivilegedOperation, targetPath, err)
+	}
+
+	return err
+}
+
+// isWritableDir reports whether a file can be created in dir.
+func isWritableDir(dir string) bool {
+	probe, err := os.CreateTemp(dir, ".goUpdater-probe-*")
+	if err != nil {
+		return false
+	}
+
+	_ = probe.Close()
+	_ = os.Remove(probe.Name())
+
+	return true
+}
+
 // RequestSudo is deprecated. Use RequestElevation instead.
 func RequestSudo() error {
 	return RequestElevation()
 }
+
+// resolveSudoPath returns the elevation binary to use.
+// The --sudo-path flag takes precedence over GOUPDATER_SUDO_PATH, which takes precedence over the tool
+// selected with --elevation-tool or GOUPDATER_ELEVATION_TOOL, which takes precedence over the defaults.
+func resolveSudoPath() (string, error) {
+	sudoPathMutex.Lock()
+	flagPath, flagTool := sudoPathFlag, elevationToolFlag
+	sudoPathMutex.Unlock()
+
+	envPath := os.Getenv(sudoPathEnvVar)
+	if flagPath != "" || envPath != "" {
+		return selectSudoPath(flagPath, envPath)
+	}
+
+	candidates, err := toolCandidates(flagTool, os.Getenv(toolEnvVar))
+	if err != nil {
+		return "", err
+	}
+
+	return selectDefaultPath(candidates)
+}
+
+// toolCandidates returns the paths to try for the tool selected by the flag or, failing that,
+// the environment. Without a selection, defaultElevationPaths is returned.
+func toolCandidates(flagTool, envTool string) ([]string, error) {
+	tool := flagTool
+	if tool == "" {
+		tool = envTool
+	}
+
+	if tool == "" {
+		return defaultElevationPaths, nil
+	}
+
+	candidates := elevationToolPaths[tool]
+	if candidates == nil {
+		return nil, fmt.Errorf("%s=%q: %w", toolEnvVar, tool, ErrUnknownElevationTool)
+	}
+
+	logger.Debugf("Using selected elevation tool: %s", tool)
+
+	return candidates, nil
+}
+
+// selectSudoPath picks the elevation binary from the flag and environment values and validates it.
+// Without either, the first valid entry of defaultElevationPaths is used.
+func selectSudoPath(flagPath, envPath string) (string, error) {
+	path := flagPath
+	if path == "" {
+		path = envPath
+	}
+
+	if path == "" {
+		return selectDefaultPath(defaultElevationPaths)
+	}
+
+	err := validateSudoPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// selectDefaultPath returns the first valid elevation binary among candidates.
+// If none is valid, the error for the first candidate is returned, since sudo is the expected tool.
+func selectDefaultPath(candidates []string) (string, error) {
+	var firstErr error
+
+	for _, path := range candidates {
+		err := validateSudoPath(path)
+		if err == nil {
+			return path, nil
+		}
+
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return "", firstErr
+}
+
+// validateSudoPath checks that the path exists and is an executable regular file that only a trusted
+// user can modify; see checkOwnership.
+func validateSudoPath(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return &ElevationError{Path: path, Reason: "elevation binary not found", Err: err}
+	}
+
+	if !info.Mode().IsRegular() || info.Mode().Perm()&executableMask == 0 {
+		return &ElevationError{Path: path, Reason: "elevation binary is not executable", Err: errNotExecutable}
+	}
+
+	return checkOwnership(path, info)
+}
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -307,3 +309,436 @@ func TestRequestSudo_Deprecated(t *testing.T) {
 	// Should behave the same as RequestElevation
 	_ = err // We don't assert since it depends on runtime conditions
 }
+
+func TestSelectSudoPath(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+
+	executable := filepath.Join(tempDir, "sudo")
+
+	err := os.WriteFile(executable, []byte("#!/bin/sh\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//nolint:gosec // G302: executable permissions required for test binary
+	err = os.Chmod(executable, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonExecutable := filepath.Join(tempDir, "not-sudo")
+
+	err = os.WriteFile(nonExecutable, []byte("data"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		flagPath string
+		envPath  string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "flag takes precedence over environment",
+			flagPath: executable,
+			envPath:  nonExecutable,
+			want:     executable,
+			wantErr:  false,
+		},
+		{
+			name:     "environment used when flag is empty",
+			flagPath: "",
+			envPath:  executable,
+			want:     executable,
+			wantErr:  false,
+		},
+		{
+			name:     "missing path",
+			flagPath: filepath.Join(tempDir, "missing"),
+			envPath:  "",
+			want:     "",
+			wantErr:  true,
+		},
+		{
+			name:     "not executable",
+			flagPath: nonExecutable,
+			envPath:  "",
+			want:     "",
+			wantErr:  true,
+		},
+		{
+			name:     "directory",
+			flagPath: tempDir,
+			envPath:  "",
+			want:     "",
+			wantErr:  true,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := selectSudoPath(testCase.flagPath, testCase.envPath)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("selectSudoPath() error = %v, wantErr %v", err, testCase.wantErr)
+			}
+
+			if err != nil {
+				var elevationErr *ElevationError
+				if !errors.As(err, &elevationErr) {
+					t.Errorf("expected ElevationError, got %T", err)
+				}
+			}
+
+			if got != testCase.want {
+				t.Errorf("selectSudoPath() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestSelectDefaultPath(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	sudo := filepath.Join(tempDir, "sudo")
+	doas := filepath.Join(tempDir, "doas")
+
+	err := os.WriteFile(doas, []byte("#!/bin/sh\n"), 0755) // #nosec G306 -- executable test binary
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := selectDefaultPath([]string{sudo, doas})
+	if err != nil {
+		t.Fatalf("selectDefaultPath() error = %v", err)
+	}
+
+	if got != doas {
+		t.Errorf("selectDefaultPath() = %q, want doas fallback %q", got, doas)
+	}
+
+	err = os.WriteFile(sudo, []byte("#!/bin/sh\n"), 0755) // #nosec G306 -- executable test binary
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = selectDefaultPath([]string{sudo, doas})
+	if err != nil {
+		t.Fatalf("selectDefaultPath() error = %v", err)
+	}
+
+	if got != sudo {
+		t.Errorf("selectDefaultPath() = %q, want sudo %q", got, sudo)
+	}
+
+	_, err = selectDefaultPath([]string{filepath.Join(tempDir, "missing")})
+
+	var elevationErr *ElevationError
+	if !errors.As(err, &elevationErr) {
+		t.Errorf("expected ElevationError when no candidate exists, got %v", err)
+	}
+}
+
+func TestToolCandidates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		flagTool string
+		envTool  string
+		want     []string
+		wantErr  bool
+	}{
+		{name: "automatic detection", flagTool: "", envTool: "", want: defaultElevationPaths, wantErr: false},
+		{name: "pkexec from environment", flagTool: "", envTool: "pkexec", want: []string{"/usr/bin/pkexec"},
+			wantErr: false},
+		{name: "flag takes precedence", flagTool: "sudo", envTool: "pkexec", want: []string{defaultSudoPath},
+			wantErr: false},
+		{name: "unknown tool", flagTool: "", envTool: "su", want: nil, wantErr: true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := toolCandidates(testCase.flagTool, testCase.envTool)
+			if testCase.wantErr != errors.Is(err, ErrUnknownElevationTool) {
+				t.Fatalf("toolCandidates() error = %v, wantErr %t", err, testCase.wantErr)
+			}
+
+			if !slices.Equal(got, testCase.want) {
+				t.Errorf("toolCandidates() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidateArgs(t *testing.T) {
+	t.Parallel()
+
+	allowed := []string{"--install-dir", "-d", "--auto-install"}
+
+	tests := []struct {
+		name    string
+		args    []string
+		allowed []string
+		wantErr bool
+	}{
+		{name: "no allowlist", args: []string{"update", "--anything"}, allowed: nil, wantErr: false},
+		{name: "allowed flags", args: []string{"update", "--install-dir", "/opt/go", "-d=/opt/go", "--auto-install"},
+			allowed: allowed, wantErr: false},
+		{name: "flag not allowed", args: []string{"update", "--sudo-path=/tmp/sudo"}, allowed: allowed, wantErr: true},
+		{name: "shorthand with value", args: []string{"update", "-d/opt/go"}, allowed: allowed, wantErr: false},
+		{name: "shorthand not allowed", args: []string{"update", "-x"}, allowed: allowed, wantErr: true},
+		{name: "positional after terminator", args: []string{"install", "--", "--not-a-flag"}, allowed: allowed,
+			wantErr: false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateArgs(testCase.args, testCase.allowed)
+			if testCase.wantErr != errors.Is(err, ErrArgNotAllowed) {
+				t.Errorf("validateArgs() error = %v, wantErr %t", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+// TestElevateRejectsArgNotAllowed is not parallel because it sets the package-level allowlist.
+func TestElevateRejectsArgNotAllowed(t *testing.T) {
+	SetAllowedArgs([]string{"--install-dir", "-d"})
+
+	t.Cleanup(func() { SetAllowedArgs(nil) })
+
+	executed := false
+
+	args := []string{"update", "--audit-log=/tmp/log"}
+
+	err := elevate("/usr/bin/sudo", "/usr/local/bin/goUpdater", args, func(string, string, []string) error {
+		executed = true
+
+		return nil
+	})
+	if !errors.Is(err, ErrArgNotAllowed) {
+		t.Errorf("elevate() error = %v, want %v", err, ErrArgNotAllowed)
+	}
+
+	if executed {
+		t.Error("elevate() re-executed with an argument outside the allowlist")
+	}
+}
+
+func TestStripElevationFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "no elevation flags", args: []string{"update", "-d", "/opt/go"}, want: []string{"update", "-d", "/opt/go"}},
+		{name: "value after equals", args: []string{"--sudo-path=/usr/bin/sudo", "update"}, want: []string{"update"}},
+		{name: "separate value", args: []string{"--elevation-tool", "doas", "update"}, want: []string{"update"}},
+		{name: "boolean flag", args: []string{"--no-elevate=false", "update"}, want: []string{"update"}},
+		{name: "positional after terminator", args: []string{"install", "--", "--sudo-path"},
+			want: []string{"install", "--", "--sudo-path"}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := stripElevationFlags(testCase.args)
+			if !slices.Equal(got, testCase.want) {
+				t.Errorf("stripElevationFlags() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+// TestElevateForwardsArgs is not parallel because it sets the package-level forwarded arguments.
+func TestElevateForwardsArgs(t *testing.T) {
+	SetForwardedArgs([]string{"--tmpdir=/var/tmp"})
+
+	t.Cleanup(func() { SetForwardedArgs(nil) })
+
+	var got []string
+
+	args := []string{"--elevation-tool", "doas", "update", "-d", "/opt/go"}
+
+	err := elevate("/usr/bin/sudo", "/usr/local/bin/goUpdater", args, func(_, _ string, args []string) error {
+		got = args
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("elevate() error = %v", err)
+	}
+
+	want := []string{"update", "-d", "/opt/go", "--tmpdir=/var/tmp"}
+	if !slices.Equal(got, want) {
+		t.Errorf("elevated args = %v, want %v", got, want)
+	}
+}
+
+func TestValidateSudoPathOwnership(t *testing.T) {
+	t.Parallel()
+
+	t.Run("world-writable", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "sudo")
+
+		err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		//nolint:gosec // G302: deliberately unsafe permissions under test
+		err = os.Chmod(path, 0777)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = validateSudoPath(path)
+		if !errors.Is(err, errUnsafeOwnership) {
+			t.Errorf("validateSudoPath() error = %v, want %v", err, errUnsafeOwnership)
+		}
+	})
+
+	t.Run("owned by another user", func(t *testing.T) {
+		t.Parallel()
+
+		if !IsRoot() {
+			t.Skip("changing file ownership requires root")
+		}
+
+		path := filepath.Join(t.TempDir(), "sudo")
+
+		err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755) // #nosec G306 -- executable test binary
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = os.Chown(path, 65534, 65534)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = validateSudoPath(path)
+		if !errors.Is(err, errUnsafeOwnership) {
+			t.Errorf("validateSudoPath() error = %v, want %v", err, errUnsafeOwnership)
+		}
+	})
+}
+
+func TestRequiresElevation(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+
+	tests := []struct {
+		name       string
+		targetPath string
+	}{
+		{
+			name:       "existing writable directory",
+			targetPath: tempDir,
+		},
+		{
+			name:       "missing directory under writable parent",
+			targetPath: filepath.Join(tempDir, "local", "go"),
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if RequiresElevation(testCase.targetPath) {
+				t.Errorf("RequiresElevation(%s) = true, want false", testCase.targetPath)
+			}
+		})
+	}
+}
+
+func TestElevateIfRequired_WritableTarget(t *testing.T) {
+	t.Parallel()
+
+	called := false
+
+	err := ElevateIfRequired(t.TempDir(), func() error {
+		called = true
+
+		return errCallback
+	})
+	if !called {
+		t.Error("expected callback to be executed without elevation")
+	}
+
+	if !errors.Is(err, errCallback) {
+		t.Errorf("expected callback error %v, got %v", errCallback, err)
+	}
+}
+
+// TestElevationDisabled is not parallel because it sets GO_UPDATER_NO_ELEVATE and the global flag.
+func TestElevationDisabled(t *testing.T) {
+	tests := []struct {
+		name string
+		flag bool
+		env  string
+		want bool
+	}{
+		{name: "default", flag: false, env: "", want: false},
+		{name: "flag", flag: true, env: "", want: true},
+		{name: "env one", flag: false, env: "1", want: true},
+		{name: "env true", flag: false, env: "true", want: true},
+		{name: "env zero", flag: false, env: "0", want: false},
+		{name: "env invalid", flag: false, env: "yes please", want: false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv(noElevateEnvVar, testCase.env)
+			SetNoElevate(testCase.flag)
+			t.Cleanup(func() { SetNoElevate(false) })
+
+			got := elevationDisabled()
+			if got != testCase.want {
+				t.Errorf("elevationDisabled() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+// TestElevateAndExecute_NoElevate is not parallel because it sets GO_UPDATER_NO_ELEVATE.
+func TestElevateAndExecute_NoElevate(t *testing.T) {
+	if IsRoot() {
+		t.Skip("Elevation is never needed when running as root")
+	}
+
+	t.Setenv(noElevateEnvVar, "1")
+
+	called := false
+
+	err := ElevateAndExecute(func() error {
+		called = true
+
+		return nil
+	})
+	if !errors.Is(err, ErrElevationRequired) {
+		t.Errorf("ElevateAndExecute() error = %v, want %v", err, ErrElevationRequired)
+	}
+
+	if called {
+		t.Error("expected callback not to run without elevation")
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+//go:build !windows
+
+package privileges
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/nicholas-fedor/goUpdater/internal/logger"
+)
+
+// isRoot reports whether the effective user is root.
+func isRoot() bool {
+	return os.Geteuid() == 0
+}
+
+// writableByOthers is the group and world write permission bits.
+const writableByOthers = 0022
+
+// elevationOwnerUID is the uid that must own the elevation binary: root. Tests set it to their own uid,
+// so that binaries they create in a temp directory are accepted.
+var elevationOwnerUID = 0 //nolint:gochecknoglobals
+
+// checkOwnership refuses an elevation binary that is group- or world-writable, or not owned by root, since
+// its owner, even the current user, could replace it with a binary that captures the password or runs
+// arbitrary commands as root.
+func checkOwnership(path string, info os.FileInfo) error {
+	return checkOwner(path, info, elevationOwnerUID)
+}
+
+// checkOwner implements checkOwnership, requiring the binary to be owned by ownerUID.
+func checkOwner(path string, info os.FileInfo, ownerUID int) error {
+	if info.Mode().Perm()&writableByOthers != 0 {
+		return &ElevationError{
+			Path:   path,
+			Reason: fmt.Sprintf("elevation binary is group- or world-writable (%v)", info.Mode().Perm()),
+			Err:    errUnsafeOwnership,
+		}
+	}
+
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+
+	if int(stat.Uid) != ownerUID {
+		return &ElevationError{
+			Path:   path,
+			Reason: fmt.Sprintf("elevation binary is owned by uid %d, not uid %d", stat.Uid, ownerUID),
+			Err:    errUnsafeOwnership,
+		}
+	}
+
+	return nil
+}
+
+// elevationTool returns the path of the elevation binary: sudo, doas, or pkexec (see resolveSudoPath).
+func elevationTool() (string, error) {
+	return resolveSudoPath()
+}
+
+// execElevated replaces the current process with exePath and args run through the elevation binary at
+// toolPath, as returned by elevationTool. It only returns on failure.
+func execElevated(toolPath, exePath string, args []string) error {
+	tool := filepath.Base(toolPath)
+	logger.Debugf("Using %s binary: %s", tool, toolPath)
+
+	// Prepare the command arguments: the tool followed by the executable and its args.
+	// pkexec requires the absolute path resolved by the caller.
+	argv := append([]string{tool, exePath}, args...)
+	logger.Debugf("Elevation command args: %v", argv)
+
+	// Use syscall.Exec to replace the current process entirely with the elevation tool
+	// This is necessary for sudo to work properly and maintain the process environment
+	// gosec: G204 - Subprocess launched with variable is acceptable here as we control the args
+	logger.Debugf("Executing with %s", tool)
+
+	err := syscall.Exec(toolPath, argv, os.Environ()) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to execute with %s: %w", tool, err)
+	}
+
+	// This point should never be reached if syscall.Exec succeeds
+	return nil
+}
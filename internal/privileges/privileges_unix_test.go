@@ -0,0 +1,50 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+//go:build !windows
+
+package privileges
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestMain accepts elevation binaries owned by the test user, which creates them in temp directories.
+// Production code requires root; see TestCheckOwnerRequiresRoot.
+func TestMain(m *testing.M) {
+	elevationOwnerUID = os.Geteuid()
+
+	os.Exit(m.Run())
+}
+
+func TestCheckOwnerRequiresRoot(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "sudo")
+
+	err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755) // #nosec G306 -- executable test binary
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Unprivileged runs already own the file as a non-root user; as root, hand it to nobody.
+	if IsRoot() {
+		err = os.Chown(path, 65534, 65534)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = checkOwner(path, info, 0)
+	if !errors.Is(err, errUnsafeOwnership) {
+		t.Errorf("checkOwner() error = %v, want %v for a binary not owned by root", err, errUnsafeOwnership)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+//go:build windows
+
+package privileges
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/nicholas-fedor/goUpdater/internal/logger"
+	"golang.org/x/sys/windows"
+)
+
+// runasVerb is the ShellExecute verb that relaunches a program with administrator privileges.
+const runasVerb = "runas"
+
+// isRoot reports whether the process token is elevated from a UAC perspective.
+func isRoot() bool {
+	return windows.GetCurrentProcessToken().IsElevated()
+}
+
+// checkOwnership is a no-op on Windows, where elevation does not run an external binary.
+func checkOwnership(string, os.FileInfo) error {
+	return nil
+}
+
+// elevationTool returns "runas", the ShellExecute verb that shows the UAC prompt; Windows elevates
+// without an external binary.
+func elevationTool() (string, error) {
+	return runasVerb, nil
+}
+
+// execElevated relaunches exePath with args through the "runas" verb, which shows
+// the UAC prompt. Windows cannot replace a running process, so the elevated copy continues in its own
+// console window and this process exits once it has been launched. It only returns on failure.
+func execElevated(_, exePath string, args []string) error {
+	escaped := make([]string, 0, len(args))
+	for _, arg := range args {
+		escaped = append(escaped, syscall.EscapeArg(arg))
+	}
+
+	logger.Debugf("Requesting UAC elevation for %s with args: %v", exePath, escaped)
+
+	verb, err := windows.UTF16PtrFromString(runasVerb)
+	if err != nil {
+		return fmt.Errorf("failed to encode verb: %w", err)
+	}
+
+	file, err := windows.UTF16PtrFromString(exePath)
+	if err != nil {
+		return fmt.Errorf("failed to encode executable path: %w", err)
+	}
+
+	params, err := windows.UTF16PtrFromString(strings.Join(escaped, " "))
+	if err != nil {
+		return fmt.Errorf("failed to encode arguments: %w", err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	dir, err := windows.UTF16PtrFromString(cwd)
+	if err != nil {
+		return fmt.Errorf("failed to encode working directory: %w", err)
+	}
+
+	err = windows.ShellExecute(0, verb, file, params, dir, windows.SW_NORMAL)
+	if err != nil {
+		return &ElevationError{Path: exePath, Reason: "UAC elevation was refused or failed", Err: err}
+	}
+
+	logger.Info("Continuing with administrator privileges in a new window")
+	os.Exit(0)
+
+	return nil
+}
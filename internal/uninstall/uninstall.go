@@ -8,14 +8,60 @@ package uninstall
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
 
 	"github.com/nicholas-fedor/goUpdater/internal/logger"
+	"github.com/nicholas-fedor/goUpdater/internal/verify"
 )
 
 // errInstallationNotFound indicates the Go installation was not found.
 var errInstallationNotFound = errors.New("installation not found")
 
+// ErrNotGoInstallation indicates a directory without the VERSION file of a Go distribution.
+var ErrNotGoInstallation = errors.New("not a Go installation")
+
+// ValidateInstallation checks that installDir holds a Go distribution before it is removed,
+// so that a mistyped --install-dir cannot delete an arbitrary directory. The distribution's VERSION
+// file must name a Go release, such as "go1.21.0", or a development build; the version is returned.
+func ValidateInstallation(installDir string) (string, error) {
+	version, err := verify.ReadVersionFile(installDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("%s has no VERSION file: %w", installDir, ErrNotGoInstallation)
+	}
+
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return "", fmt.Errorf("failed to read the Go version in %s: %w", installDir, err)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("%s has an unrecognized VERSION file: %w", installDir, ErrNotGoInstallation)
+	}
+
+	return version, nil
+}
+
+// IsGoTree reports whether dir shows the markers of a Go distribution: a VERSION file read by
+// ValidateInstallation, or both the src and bin directories. It tells a broken installation apart
+// from an unrelated directory.
+func IsGoTree(dir string) bool {
+	_, err := ValidateInstallation(dir)
+	if err == nil {
+		return true
+	}
+
+	for _, name := range []string{"src", "bin"} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil || !info.IsDir() {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Remove removes the Go installation from the specified directory.
 // It returns an error if the removal fails or if the directory does not exist.
 func Remove(installDir string) error {
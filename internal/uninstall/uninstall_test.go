@@ -4,6 +4,7 @@
 package uninstall
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -223,6 +224,92 @@ func setupNestedDirectories(t *testing.T, dir string) {
 	}
 }
 
+func TestValidateInstallation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+		wantErr  error
+	}{
+		{name: "go distribution", content: "go1.21.0\ntime 2023-08-08T19:22:25Z\n", expected: "go1.21.0", wantErr: nil},
+		{
+			name:     "development build",
+			content:  "devel go1.23-abc123 Mon Jan 1 00:00:00 2024 +0000\n",
+			expected: "devel go1.23-abc123",
+			wantErr:  nil,
+		},
+		{name: "unrecognized version", content: "1.0.0\n", expected: "", wantErr: ErrNotGoInstallation},
+		{name: "trailing text", content: "go1.21.0 extra\n", expected: "", wantErr: ErrNotGoInstallation},
+		{name: "no version file", content: "", expected: "", wantErr: ErrNotGoInstallation},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			installDir := t.TempDir()
+
+			if testCase.content != "" {
+				err := os.WriteFile(filepath.Join(installDir, "VERSION"), []byte(testCase.content), 0600)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			version, err := ValidateInstallation(installDir)
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("ValidateInstallation() error = %v, want %v", err, testCase.wantErr)
+			}
+
+			if version != testCase.expected {
+				t.Errorf("ValidateInstallation() = %q, want %q", version, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestIsGoTree(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		files []string
+		want  bool
+	}{
+		{name: "VERSION file", files: []string{"VERSION"}, want: true},
+		{name: "src and bin directories", files: []string{"src/", "bin/"}, want: true},
+		{name: "bin directory only", files: []string{"bin/"}, want: false},
+		{name: "unrelated files", files: []string{"notes.txt"}, want: false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			for _, name := range testCase.files {
+				var err error
+				if strings.HasSuffix(name, "/") {
+					err = os.Mkdir(filepath.Join(dir, name), 0750)
+				} else {
+					err = os.WriteFile(filepath.Join(dir, name), []byte("go1.21.0\n"), 0600)
+				}
+
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if got := IsGoTree(dir); got != testCase.want {
+				t.Errorf("IsGoTree() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
 func TestRemove(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,81 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/nicholas-fedor/goUpdater/internal/logger"
+)
+
+// ErrPostInstallCommand indicates that the post-install command failed after Go was installed and verified.
+var ErrPostInstallCommand = errors.New("post-install command failed")
+
+// postInstall holds the shell command set by SetPostInstallCommand.
+//
+//nolint:gochecknoglobals
+var (
+	postInstallMutex   sync.Mutex
+	postInstallCommand string
+)
+
+// SetPostInstallCommand sets a shell command to run after each later update or install that changed Go,
+// once the new installation has been verified. The command runs through sh -c, or cmd /C on Windows,
+// with GOUPDATER_INSTALL_DIR and GOUPDATER_GO_VERSION set and the installation's bin directory first on
+// PATH, so that "go" is the new toolchain. An empty command disables the hook.
+func SetPostInstallCommand(command string) {
+	postInstallMutex.Lock()
+
+	postInstallCommand = command
+
+	postInstallMutex.Unlock()
+}
+
+// runPostInstallCommand runs the command set by SetPostInstallCommand, if any, for goVersion in installDir.
+// Its combined output is logged line by line; a failure, including the exit status, wraps ErrPostInstallCommand.
+func runPostInstallCommand(ctx context.Context, installDir, goVersion string) error {
+	postInstallMutex.Lock()
+	command := postInstallCommand
+	postInstallMutex.Unlock()
+
+	if command == "" {
+		return nil
+	}
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	logger.Infof("Running post-install command: %s", command)
+
+	cmd := exec.CommandContext(ctx, shell, flag, command) // #nosec G204
+	cmd.Env = append(os.Environ(),
+		"GOUPDATER_INSTALL_DIR="+installDir,
+		"GOUPDATER_GO_VERSION="+goVersion,
+		"PATH="+filepath.Join(installDir, "bin")+string(os.PathListSeparator)+os.Getenv("PATH"),
+	)
+
+	output, err := cmd.CombinedOutput()
+
+	for line := range strings.SplitSeq(strings.TrimRight(string(output), "\r\n"), "\n") {
+		if line != "" {
+			logger.Infof("post-install: %s", strings.TrimRight(line, "\r"))
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrPostInstallCommand, command, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright © 2025 Nicholas Fedor
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package update
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestRunPostInstallCommand is not parallel because SetPostInstallCommand changes package-level state.
+func TestRunPostInstallCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test commands use POSIX sh syntax")
+	}
+
+	t.Cleanup(func() { SetPostInstallCommand("") })
+
+	installDir := t.TempDir()
+
+	err := runPostInstallCommand(context.Background(), installDir, "go1.21.0")
+	if err != nil {
+		t.Fatalf("runPostInstallCommand() without a command error = %v", err)
+	}
+
+	SetPostInstallCommand(`echo "$GOUPDATER_GO_VERSION" > "$GOUPDATER_INSTALL_DIR/hook"`)
+
+	err = runPostInstallCommand(context.Background(), installDir, "go1.21.0")
+	if err != nil {
+		t.Fatalf("runPostInstallCommand() unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(installDir, "hook"))
+	if err != nil || string(content) != "go1.21.0\n" {
+		t.Errorf("hook wrote %q (err %v), want %q", content, err, "go1.21.0\n")
+	}
+
+	SetPostInstallCommand("echo failing; exit 3")
+
+	err = runPostInstallCommand(context.Background(), installDir, "go1.21.0")
+	if !errors.Is(err, ErrPostInstallCommand) {
+		t.Errorf("runPostInstallCommand() error = %v, want %v", err, ErrPostInstallCommand)
+	}
+}
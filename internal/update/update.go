@@ -6,10 +6,16 @@
 package update
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/nicholas-fedor/goUpdater/internal/download"
 	"github.com/nicholas-fedor/goUpdater/internal/install"
@@ -17,141 +23,637 @@ import (
 	"github.com/nicholas-fedor/goUpdater/internal/privileges"
 	"github.com/nicholas-fedor/goUpdater/internal/uninstall"
 	"github.com/nicholas-fedor/goUpdater/internal/verify"
-	"github.com/nicholas-fedor/goUpdater/internal/version"
 )
 
+// Action describes what an update did.
+type Action string
+
+// Update actions reported in Report.Action.
+const (
+	ActionUpdated     Action = "updated"     // An existing installation was replaced
+	ActionInstalled   Action = "installed"   // Go was installed where none was present
+	ActionSkipped     Action = "skipped"     // The installed version was already current
+	ActionReinstalled Action = "reinstalled" // The installed version was installed again, as set with SetForce
+	ActionRolledBack  Action = "rolledBack"  // The previous installation was restored by Rollback
+)
+
+// Report describes the outcome of an update.
+// FromVersion is empty when Go was not previously installed.
+type Report struct {
+	InstallDir  string `json:"installDir"`
+	FromVersion string `json:"fromVersion"`
+	ToVersion   string `json:"toVersion"`
+	Action      Action `json:"action"`
+	DurationMs  int64  `json:"durationMs"`
+}
+
+// Availability reports whether a newer stable Go release than the installed one is available.
+// Installed is empty when Go is not installed, in which case an update is always available.
+type Availability struct {
+	Installed       string `json:"installed"`
+	Latest          string `json:"latest"`
+	UpdateAvailable bool   `json:"updateAvailable"`
+}
+
+// Plan describes what an update would do, as computed by PlanUpdate.
+// ArchiveURL and DownloadSize are empty when Action is ActionSkipped.
+type Plan struct {
+	InstallDir   string `json:"installDir"`
+	FromVersion  string `json:"fromVersion"`
+	ToVersion    string `json:"toVersion"`
+	Action       Action `json:"action"`
+	ArchiveURL   string `json:"archiveUrl"`
+	DownloadSize int64  `json:"downloadSize"`
+}
+
+// Stage names a step of an update, as reported to the function set with SetStageTimer.
+type Stage string
+
+// Update stages, in the order they run.
+const (
+	StageCheck     Stage = "check"     // Resolving the installed and target versions
+	StageDownload  Stage = "download"  // Downloading the archive and checking its signature
+	StageUninstall Stage = "uninstall" // Moving the previous installation aside as a backup
+	StageInstall   Stage = "install"   // Extracting the archive into the install directory
+	StageVerify    Stage = "verify"    // Checking that the new installation reports the expected version
+)
+
+// StageFunc receives the duration of an update stage, whether or not the stage succeeded.
+type StageFunc func(stage Stage, duration time.Duration)
+
+// backupSuffix is appended to the install directory to name the backup of the previous installation,
+// which is restored if the new installation cannot be verified. With SetKeepBackup it is kept afterwards
+// for Rollback.
+const backupSuffix = ".bak"
+
+// rollbackSuffix is appended to the install directory to name the current installation while Rollback
+// swaps the backup into place.
+const rollbackSuffix = ".rollback"
+
 var (
 	// ErrGoNotInstalled indicates that Go is not installed in the specified directory.
 	ErrGoNotInstalled = errors.New("Go is not installed")
+
+	// ErrBrokenInstallation indicates that the install directory holds files but not a working Go,
+	// e.g. after an extraction that was interrupted.
+	ErrBrokenInstallation = errors.New("the Go installation is broken")
+
+	// ErrAlreadyUpToDate indicates that the installed Go is already the latest version
+	// and no update was performed. Callers should treat it as a successful no-op.
+	ErrAlreadyUpToDate = errors.New("Go is already up to date")
+
+	// ErrVersionRequired indicates that ToVersion was called without a target version.
+	ErrVersionRequired = errors.New("a target Go version is required")
+
+	// ErrNoBackup indicates that there is no previous installation to roll back to.
+	ErrNoBackup = errors.New("no previous Go installation to roll back to")
 )
 
+// signatureKey holds the OpenPGP public key path set by SetSignatureKey.
+//
+//nolint:gochecknoglobals
+var (
+	signatureKeyMutex sync.Mutex
+	signatureKeyPath  string
+)
+
+// force holds the setting made with SetForce.
+//
+//nolint:gochecknoglobals
+var (
+	forceMutex     sync.Mutex
+	forceReinstall bool
+)
+
+// keepBackup holds the setting made with SetKeepBackup.
+//
+//nolint:gochecknoglobals
+var (
+	keepBackupMutex sync.Mutex
+	keepBackup      bool
+)
+
+// stageTimer holds the function set by SetStageTimer.
+//
+//nolint:gochecknoglobals
+var (
+	stageTimerMutex sync.Mutex
+	stageTimer      StageFunc
+)
+
+// SetStageTimer sets a function that receives the duration of each stage of later updates,
+// e.g. to record them as metrics. A nil timer stops reporting.
+func SetStageTimer(timer StageFunc) {
+	stageTimerMutex.Lock()
+
+	stageTimer = timer
+
+	stageTimerMutex.Unlock()
+}
+
+// timeStage runs a stage and reports its duration to the stage timer, if one is set.
+func timeStage(stage Stage, run func() error) error {
+	stageTimerMutex.Lock()
+	timer := stageTimer
+	stageTimerMutex.Unlock()
+
+	start := time.Now()
+	err := run()
+
+	if timer != nil {
+		timer(stage, time.Since(start))
+	}
+
+	return err
+}
+
+// SetSignatureKey enables verification of the downloaded archive's detached OpenPGP signature
+// against the public key at path before the existing installation is touched.
+// An empty path disables signature verification.
+func SetSignatureKey(path string) {
+	signatureKeyMutex.Lock()
+
+	signatureKeyPath = path
+
+	signatureKeyMutex.Unlock()
+}
+
+// SetForce makes later updates install the target version even when the installed version is already
+// current or newer, e.g. to repair a tree that still reports the right version but is damaged.
+// Replacing the same version is reported as ActionReinstalled.
+func SetForce(force bool) {
+	forceMutex.Lock()
+
+	forceReinstall = force
+
+	forceMutex.Unlock()
+}
+
+// forced reports whether SetForce enabled reinstalling.
+func forced() bool {
+	forceMutex.Lock()
+	defer forceMutex.Unlock()
+
+	return forceReinstall
+}
+
+// SetKeepBackup makes later updates keep the replaced installation at the install directory plus ".bak"
+// once the new one has been verified, so that Rollback can restore it. By default the backup is removed.
+func SetKeepBackup(keep bool) {
+	keepBackupMutex.Lock()
+
+	keepBackup = keep
+
+	keepBackupMutex.Unlock()
+}
+
+// keepingBackup reports whether SetKeepBackup enabled keeping backups.
+func keepingBackup() bool {
+	keepBackupMutex.Lock()
+	defer keepBackupMutex.Unlock()
+
+	return keepBackup
+}
+
 // Go performs a complete Go update: checks if Go is installed, compares versions,
 // downloads the latest version if needed, removes the existing installation,
 // installs the new version, verifies it, and logs success message.
 // installDir is the directory where Go should be installed (e.g., "/usr/local/go").
 // autoInstall enables automatic installation if Go is not present.
+// If the installed version is already the latest, it returns an error wrapping ErrAlreadyUpToDate.
 func Go(installDir string, autoInstall bool) error {
-	logger.Debugf("Starting Go update process: installDir=%s, autoInstall=%t", installDir, autoInstall)
+	return GoVersion(installDir, "", autoInstall)
+}
+
+// GoContext performs Go, giving up once ctx is done: the release feed and archive downloads, the
+// extraction, and the go commands run for verification all stop, and a replaced installation is
+// restored. Use context.WithTimeout to bound the whole update.
+func GoContext(ctx context.Context, installDir string, autoInstall bool) error {
+	return skippedAsError(goVersion(ctx, installDir, "", autoInstall, false))
+}
 
-	installedVersion, latestVersionStr, err := checkAndPrepare(installDir, autoInstall)
+// GoVersion performs the update workflow of Go, targeting the given published Go version
+// (e.g., "go1.21.13") instead of the latest stable release. An empty targetVersion targets the latest.
+// If the installed version is already at or beyond the target, it returns an error wrapping ErrAlreadyUpToDate.
+func GoVersion(installDir, targetVersion string, autoInstall bool) error {
+	return skippedAsError(goVersion(context.Background(), installDir, targetVersion, autoInstall, false))
+}
+
+// GoVersionReport performs GoVersion and reports what was done instead of returning ErrAlreadyUpToDate:
+// the versions involved, whether Go was updated, installed, or left alone, and how long it took.
+func GoVersionReport(installDir, targetVersion string, autoInstall bool) (*Report, error) {
+	return GoVersionReportContext(context.Background(), installDir, targetVersion, autoInstall)
+}
+
+// GoVersionReportContext performs GoVersionReport, giving up once ctx is done; see GoContext.
+func GoVersionReportContext(ctx context.Context, installDir, targetVersion string, autoInstall bool) (*Report, error) {
+	return goVersion(ctx, installDir, targetVersion, autoInstall, false)
+}
+
+// ToVersion installs the given published Go version (e.g., "go1.21.13") into installDir even when it is
+// older than the installed version, which makes it possible to move back after a bad release.
+// If exactly that version is already installed, it returns an error wrapping ErrAlreadyUpToDate.
+func ToVersion(installDir, targetVersion string, autoInstall bool) error {
+	if targetVersion == "" {
+		return ErrVersionRequired
+	}
+
+	return skippedAsError(goVersion(context.Background(), installDir, targetVersion, autoInstall, true))
+}
+
+// skippedAsError converts a skipped update into an error wrapping ErrAlreadyUpToDate.
+func skippedAsError(report *Report, err error) error {
 	if err != nil {
-		logger.Debugf("checkAndPrepare failed: %v", err)
+		return err
+	}
+
+	if report.Action == ActionSkipped {
+		return fmt.Errorf("%w (%s)", ErrAlreadyUpToDate, report.FromVersion)
+	}
+
+	return nil
+}
+
+// goVersion runs the update workflow for GoVersion and ToVersion.
+// allowDowngrade replaces the newer-than-installed check with an exact version match.
+func goVersion(
+	ctx context.Context,
+	installDir, targetVersion string,
+	autoInstall, allowDowngrade bool,
+) (*Report, error) {
+	logger.Debugf("Starting Go update process: installDir=%s, targetVersion=%s, autoInstall=%t, allowDowngrade=%t",
+		installDir, targetVersion, autoInstall, allowDowngrade)
+
+	start := time.Now()
+
+	var installedVersion, latestVersionStr string
+
+	err := timeStage(StageCheck, func() error {
+		var err error
+
+		installedVersion, latestVersionStr, err = checkAndPrepare(ctx, installDir, targetVersion, autoInstall)
 
 		return err
+	})
+	if err != nil {
+		logger.Debugf("checkAndPrepare failed: %v", err)
+
+		return nil, err
 	}
 
 	logger.Debugf("checkAndPrepare succeeded: installedVersion=%s, latestVersionStr=%s",
 		installedVersion, latestVersionStr)
 
-	needsUpdateResult := needsUpdate(installedVersion, latestVersionStr)
-	logger.Debugf("needsUpdate result: %t", needsUpdateResult)
+	report := &Report{
+		InstallDir:  installDir,
+		FromVersion: installedVersion,
+		ToVersion:   "go" + latestVersionStr,
+		Action:      updateAction(installedVersion, latestVersionStr, allowDowngrade),
+		DurationMs:  0,
+	}
 
-	if !needsUpdateResult {
-		logger.Debug("No update needed, returning nil")
+	if report.Action == ActionSkipped {
+		logger.Debug("No update needed")
 
-		return nil
+		report.DurationMs = time.Since(start).Milliseconds()
+
+		return report, nil
 	}
 
 	logger.Debug("Update needed, proceeding to download")
 
-	archivePath, tempDir, err := downloadLatest()
+	var archivePath, tempDir string
+
+	err = timeStage(StageDownload, func() error {
+		var err error
+
+		archivePath, tempDir, err = downloadVersion(ctx, targetVersion)
+		if err != nil {
+			logger.Debugf("downloadVersion failed: %v", err)
+
+			return err
+		}
+
+		logger.Debugf("downloadVersion succeeded: archivePath=%s, tempDir=%s", archivePath, tempDir)
+
+		return verifySignature(archivePath)
+	})
+	if tempDir != "" {
+		defer func() { _ = os.RemoveAll(tempDir) }()
+	}
+
 	if err != nil {
-		logger.Debugf("downloadLatest failed: %v", err)
+		return nil, err
+	}
 
-		return err
+	err = performUpdate(ctx, archivePath, installDir, installedVersion, latestVersionStr)
+	if err != nil {
+		logger.Debugf("performUpdate failed: %v", err)
+
+		return nil, err
 	}
 
-	logger.Debugf("downloadLatest succeeded: archivePath=%s, tempDir=%s", archivePath, tempDir)
+	logger.Debug("performUpdate succeeded")
 
-	defer func() { _ = os.RemoveAll(tempDir) }()
+	install.ReportPathResolution(installDir)
 
-	err = performUpdate(archivePath, installDir, installedVersion)
+	err = runPostInstallCommand(ctx, installDir, report.ToVersion)
 	if err != nil {
-		logger.Debugf("performUpdate failed: %v", err)
+		return nil, err
+	}
+
+	report.DurationMs = time.Since(start).Milliseconds()
+
+	return report, nil
+}
+
+// PlanUpdate reports what GoVersion would do with the same arguments, without downloading the archive,
+// changing installDir, or requiring elevation. It fails in the same way GoVersion would when Go is not
+// installed and autoInstall is false, or when targetVersion is not a published release.
+func PlanUpdate(installDir, targetVersion string, autoInstall bool) (*Plan, error) {
+	ctx := context.Background()
+
+	installedVersion, latestVersionStr, err := checkAndPrepare(ctx, installDir, targetVersion, autoInstall)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{
+		InstallDir:   installDir,
+		FromVersion:  installedVersion,
+		ToVersion:    "go" + latestVersionStr,
+		Action:       updateAction(installedVersion, latestVersionStr, false),
+		ArchiveURL:   "",
+		DownloadSize: 0,
+	}
+
+	if plan.Action == ActionSkipped {
+		return plan, nil
+	}
+
+	info, err := download.ResolveDownload(plan.ToVersion, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the download for %s: %w", plan.ToVersion, err)
+	}
+
+	plan.ArchiveURL = info.URL
+	plan.DownloadSize = int64(info.Size)
+
+	return plan, nil
+}
+
+// UpdateAll updates Go to the latest stable release in each of installDirs, one after another.
+// A failure in one directory does not stop the others; the reports of the directories that succeeded
+// are returned together with an error joining the failures, each prefixed with its directory.
+func UpdateAll(installDirs []string, autoInstall bool) ([]Report, error) {
+	return UpdateAllContext(context.Background(), installDirs, autoInstall)
+}
+
+// UpdateAllContext performs UpdateAll, giving up on the current and any remaining directories once ctx is done.
+func UpdateAllContext(ctx context.Context, installDirs []string, autoInstall bool) ([]Report, error) {
+	reports := make([]Report, 0, len(installDirs))
+
+	var errs []error
+
+	for _, installDir := range installDirs {
+		logger.Infof("Updating Go in %s", installDir)
+
+		report, err := GoVersionReportContext(ctx, installDir, "", autoInstall)
+		if err != nil {
+			logger.Errorf("Failed to update Go in %s: %v", installDir, err)
+
+			errs = append(errs, fmt.Errorf("%s: %w", installDir, err))
+
+			continue
+		}
+
+		reports = append(reports, *report)
+	}
+
+	return reports, errors.Join(errs...)
+}
+
+// UpdateAllWithPrivileges performs UpdateAll, elevating once up front if any of installDirs
+// is not writable by the current user.
+func UpdateAllWithPrivileges(installDirs []string, autoInstall bool) ([]Report, error) {
+	return UpdateAllWithPrivilegesContext(context.Background(), installDirs, autoInstall)
+}
+
+// UpdateAllWithPrivilegesContext performs UpdateAllWithPrivileges, giving up once ctx is done.
+func UpdateAllWithPrivilegesContext(ctx context.Context, installDirs []string, autoInstall bool) ([]Report, error) {
+	var reports []Report
+
+	operation := func() error {
+		var err error
+
+		reports, err = UpdateAllContext(ctx, installDirs, autoInstall)
 
 		return err
 	}
 
-	logger.Debug("performUpdate succeeded")
+	if slices.ContainsFunc(installDirs, privileges.RequiresElevation) {
+		err := privileges.ElevateAndExecute(operation)
+
+		return reports, err
+	}
+
+	err := operation()
 
-	err = verify.Installation(installDir, latestVersionStr)
+	return reports, err
+}
+
+// CheckForUpdate compares the Go installed in installDir with the latest stable release without
+// downloading or changing anything.
+func CheckForUpdate(installDir string) (*Availability, error) {
+	logger.Debugf("Checking for Go updates: installDir=%s", installDir)
+
+	installedVersion, err := verify.GetInstalledVersion(installDir)
 	if err != nil {
-		logger.Debugf("verify.Installation failed: %v", err)
+		logger.Debugf("Go not found in %s: %v", installDir, err)
 
-		return fmt.Errorf("failed to verify installation: %w", err)
+		installedVersion = ""
 	}
 
-	logger.Debug("verify.Installation succeeded")
+	latest, err := download.GetLatestVersionInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest version info: %w", err)
+	}
 
-	return nil
+	updateAvailable := installedVersion == "" ||
+		download.CompareGoVersions(installedVersion, latest.Version) < 0
+
+	return &Availability{
+		Installed:       installedVersion,
+		Latest:          latest.Version,
+		UpdateAvailable: updateAvailable,
+	}, nil
 }
 
 // GoWithPrivileges performs a complete Go update workflow including privilege checking,
+// elevating only when installDir is not writable by the current user,
 // version comparison, user prompts, and success/error messaging.
 // It wraps the existing update logic and handles all display/output logic.
 // installDir is the directory where Go should be installed (e.g., "/usr/local/go").
 // autoInstall enables automatic installation if Go is not present.
+// An ErrAlreadyUpToDate result is passed through so callers can detect it with errors.Is.
 func GoWithPrivileges(installDir string, autoInstall bool) error {
-	logger.Debugf("Starting update operation: installDir=%s, autoInstall=%t", installDir, autoInstall)
+	return GoVersionWithPrivileges(installDir, "", autoInstall)
+}
+
+// GoVersionWithPrivileges performs GoWithPrivileges targeting the given Go version; see GoVersion.
+func GoVersionWithPrivileges(installDir, targetVersion string, autoInstall bool) error {
+	return withPrivileges(installDir, func() error { return GoVersion(installDir, targetVersion, autoInstall) })
+}
+
+// GoVersionReportWithPrivileges performs GoVersionReport, elevating only when installDir is not writable
+// by the current user. When elevation re-executes goUpdater, the report is produced by the elevated process.
+func GoVersionReportWithPrivileges(installDir, targetVersion string, autoInstall bool) (*Report, error) {
+	return GoVersionReportWithPrivilegesContext(context.Background(), installDir, targetVersion, autoInstall)
+}
+
+// GoVersionReportWithPrivilegesContext performs GoVersionReportWithPrivileges, giving up once ctx is done.
+// An elevated re-execution applies its own deadline, so callers should derive ctx from a flag such as
+// --timeout that the re-executed goUpdater receives as well.
+func GoVersionReportWithPrivilegesContext(
+	ctx context.Context,
+	installDir, targetVersion string,
+	autoInstall bool,
+) (*Report, error) {
+	var report *Report
+
+	err := withPrivileges(installDir, func() error {
+		var err error
+
+		report, err = GoVersionReportContext(ctx, installDir, targetVersion, autoInstall)
+
+		return err
+	})
+
+	return report, err
+}
+
+// ToVersionWithPrivileges performs ToVersion, elevating only when installDir is not writable by the current user.
+func ToVersionWithPrivileges(installDir, targetVersion string, autoInstall bool) error {
+	return withPrivileges(installDir, func() error { return ToVersion(installDir, targetVersion, autoInstall) })
+}
+
+// withPrivileges runs the update operation for installDir with elevation if required.
+// An ErrAlreadyUpToDate result is passed through without being logged as a failure.
+func withPrivileges(installDir string, operation func() error) error {
+	logger.Debugf("Starting update operation: installDir=%s", installDir)
 
-	err := privileges.ElevateAndExecute(func() error { return Go(installDir, autoInstall) })
+	var upToDateErr error
+
+	err := privileges.ElevateIfRequired(installDir, func() error {
+		err := operation()
+		if errors.Is(err, ErrAlreadyUpToDate) {
+			// Not a failure, so keep it out of the privileged operation error path
+			upToDateErr = err
+
+			return nil
+		}
+
+		return err
+	})
 	if err != nil {
-		logger.Debugf("privileges.ElevateAndExecute failed: %v", err)
+		logger.Debugf("privileges.ElevateIfRequired failed: %v", err)
 
 		return fmt.Errorf("failed to update Go: %w", err)
 	}
 
-	logger.Debug("privileges.ElevateAndExecute succeeded")
+	logger.Debug("privileges.ElevateIfRequired succeeded")
 
-	return nil
+	return upToDateErr
 }
 
-// checkAndPrepare checks if Go is installed, fetches the latest version, and determines if an update is needed.
-// It returns the installed version, latest version string, and any error encountered.
-func checkAndPrepare(installDir string, autoInstall bool) (string, string, error) {
-	installedVersion, err := checkInstallation(installDir, autoInstall)
+// checkAndPrepare checks if Go is installed, fetches the target version, and determines if an update is needed.
+// An empty targetVersion targets the latest stable release.
+// It returns the installed version, target version string, and any error encountered.
+func checkAndPrepare(ctx context.Context, installDir, targetVersion string, autoInstall bool) (string, string, error) {
+	installedVersion, err := checkInstallation(ctx, installDir, autoInstall)
 	if err != nil {
 		return "", "", err
 	}
 
-	latestVersion, err := download.GetLatestVersionInfo()
-	if err != nil {
-		return "", "", fmt.Errorf("failed to get latest version info: %w", err)
+	var target *download.GoVersionInfo
+
+	if targetVersion == "" {
+		target, err = download.GetLatestVersionInfoContext(ctx)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get latest version info: %w", err)
+		}
+	} else {
+		target, err = download.GetVersionInfoContext(ctx, targetVersion)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get version info for %s: %w", targetVersion, err)
+		}
 	}
 
-	latestVersionStr := strings.TrimPrefix(latestVersion.Version, "go")
-	logger.Debugf("Latest available version: %s", latestVersionStr)
+	latestVersionStr := strings.TrimPrefix(target.Version, "go")
+	logger.Debugf("Target version: %s", latestVersionStr)
 
 	return installedVersion, latestVersionStr, nil
 }
 
 // checkInstallation checks if Go is installed and handles auto-install logic.
-func checkInstallation(installDir string, autoInstall bool) (string, error) {
-	installedVersion, err := verify.GetInstalledVersion(installDir)
-	if err != nil {
-		logger.Debugf("Go not found in %s: %v", installDir, err)
+// An install directory that looks like a Go distribution (see uninstall.IsGoTree) but has no working go binary
+// is broken: with autoInstall or SetForce it is treated as not installed, so that a fresh installation replaces
+// it after performUpdate backs it up, and otherwise ErrBrokenInstallation is returned. Any other non-empty
+// directory is refused with uninstall.ErrNotGoInstallation, so a mistyped --install-dir is never replaced.
+func checkInstallation(ctx context.Context, installDir string, autoInstall bool) (string, error) {
+	installedVersion, err := verify.GetInstalledVersionContext(ctx, installDir)
+	if err == nil {
+		logger.Debugf("Found installed Go version: %s", installedVersion)
+
+		return installedVersion, nil
+	}
+
+	// An interrupted extraction leaves a tree whose VERSION file names the release but has no go binary
+	_, statErr := os.Stat(filepath.Join(installDir, "bin", "go"))
+	if errors.Is(statErr, fs.ErrNotExist) {
+		partialVersion, versionErr := verify.ReadVersionFile(installDir)
+		if versionErr == nil {
+			err = fmt.Errorf("partial %s installation without bin/go: %w", partialVersion, statErr)
+		}
+	}
 
-		if !autoInstall {
-			return "", fmt.Errorf("%w in %s. Use --auto-install flag to install it automatically", ErrGoNotInstalled, installDir)
+	entries, readErr := os.ReadDir(installDir)
+	if readErr == nil && len(entries) > 0 {
+		if !uninstall.IsGoTree(installDir) {
+			return "", fmt.Errorf("%s is not empty and holds no Go distribution; refusing to replace it: %w",
+				installDir, uninstall.ErrNotGoInstallation)
 		}
 
-		logger.Info("Go is not installed. Proceeding with installation.")
-	} else {
-		logger.Debugf("Found installed Go version: %s", installedVersion)
+		if !autoInstall && !forced() {
+			return "", fmt.Errorf("%w in %s (%w). Use --auto-install or --force to replace it",
+				ErrBrokenInstallation, installDir, err)
+		}
+
+		logger.Warnf("The Go installation in %s is broken (%v); replacing it with a fresh installation", installDir, err)
+
+		return "", nil
+	}
+
+	logger.Debugf("Go not found in %s: %v", installDir, err)
+
+	if !autoInstall {
+		return "", fmt.Errorf("%w in %s. Use --auto-install flag to install it automatically", ErrGoNotInstalled, installDir)
 	}
 
-	return installedVersion, nil
+	logger.Info("Go is not installed. Proceeding with installation.")
+
+	return "", nil
 }
 
-// downloadLatest downloads the latest Go archive to a temporary directory.
-// It returns the archive path, temp directory path, and any error encountered.
-func downloadLatest() (string, string, error) {
-	tempDir, err := os.MkdirTemp("", "goUpdater-*")
+// downloadVersion downloads the archive for the target Go version, or the latest when empty, to a new temp directory.
+// It returns the archive path and the temp directory, which the caller must remove.
+func downloadVersion(ctx context.Context, targetVersion string) (string, string, error) {
+	tempDir, err := download.MkdirTemp(download.TempDirPrefix + "*")
 	if err != nil {
 		return "", "", fmt.Errorf("failed to create temp directory: %w", err)
 	}
 
-	archivePath, _, err := download.GetLatest(tempDir)
+	archivePath, _, err := download.GetContext(ctx, targetVersion, tempDir)
 	if err != nil {
 		_ = os.RemoveAll(tempDir)
 
@@ -161,41 +663,287 @@ func downloadLatest() (string, string, error) {
 	return archivePath, tempDir, nil
 }
 
-// performUpdate handles the uninstallation of the existing Go installation and installation of the new version.
-// It takes the archive path, install directory, and installed version as parameters.
-func performUpdate(archivePath, installDir, installedVersion string) error {
+// verifySignature downloads and checks the archive's detached signature when a key was set with SetSignatureKey.
+func verifySignature(archivePath string) error {
+	signatureKeyMutex.Lock()
+	keyPath := signatureKeyPath
+	signatureKeyMutex.Unlock()
+
+	if keyPath == "" {
+		return nil
+	}
+
+	sigPath, err := download.GetSignature(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to download archive signature: %w", err)
+	}
+
+	err = verify.VerifySignature(archivePath, sigPath, keyPath)
+	if err != nil {
+		return fmt.Errorf("archive signature verification failed: %w", err)
+	}
+
+	logger.Info("Archive signature verified")
+
+	return nil
+}
+
+// performUpdate replaces the existing Go installation with the archive and verifies the result.
+// The existing installation is moved aside to installDir+backupSuffix rather than removed, and is restored
+// if installation or verification fails, so a failed update leaves the previous working Go in place.
+// The backup is removed only after the new installation reports expectedVersion, unless SetKeepBackup
+// asked for it to be kept so that Rollback can restore it.
+// Nothing is changed if ctx is already done, and a cancellation during installation restores the backup.
+func performUpdate(ctx context.Context, archivePath, installDir, installedVersion, expectedVersion string) error {
 	logger.Debugf("Performing update: archive=%s, installDir=%s, installedVersion=%s",
 		archivePath, installDir, installedVersion)
 
-	if installedVersion != "" {
-		logger.Debug("Uninstalling existing Go installation")
+	err := ctx.Err()
+	if err != nil {
+		return fmt.Errorf("update of %s cancelled: %w", installDir, err)
+	}
+
+	backupDir := ""
 
-		err := privileges.ElevateAndExecute(func() error { return uninstall.Remove(installDir) })
+	// checkInstallation only lets a non-empty directory through when it is a Go tree, possibly a broken one
+	// without a version; either way it is backed up rather than discarded.
+	entries, _ := os.ReadDir(installDir)
+	if installedVersion != "" || len(entries) > 0 {
+		backupDir = installDir + backupSuffix
+		logger.Debugf("Backing up existing Go installation to %s", backupDir)
+
+		err := timeStage(StageUninstall, func() error {
+			return privileges.ElevateIfRequired(installDir, func() error { return backupInstallation(installDir, backupDir) })
+		})
 		if err != nil {
-			return fmt.Errorf("failed to uninstall existing Go: %w", err)
+			return fmt.Errorf("failed to back up existing Go: %w", err)
 		}
 	}
 
+	err = installAndVerify(ctx, archivePath, installDir, expectedVersion)
+	if err != nil {
+		if backupDir != "" {
+			restoreErr := restoreInstallation(backupDir, installDir)
+			if restoreErr != nil {
+				return errors.Join(err, restoreErr)
+			}
+
+			logger.Warnf("Update failed; restored the previous Go installation (%s)", installedVersion)
+		}
+
+		return err
+	}
+
+	if backupDir != "" && keepingBackup() {
+		logger.Debugf("Kept the previous Go installation at %s for rollback", backupDir)
+	} else if backupDir != "" {
+		err = os.RemoveAll(backupDir)
+		if err != nil {
+			logger.Warnf("Failed to remove backup of the previous Go installation at %s: %v", backupDir, err)
+		}
+	}
+
+	return nil
+}
+
+// installAndVerify installs the archive into installDir and verifies that it reports expectedVersion
+// and contains the entries of a complete Go distribution.
+func installAndVerify(ctx context.Context, archivePath, installDir, expectedVersion string) error {
 	logger.Debug("Installing new Go version")
 
-	err := install.Go(archivePath, installDir)
+	err := timeStage(StageInstall, func() error { return install.GoContext(ctx, archivePath, installDir) })
 	if err != nil {
 		return fmt.Errorf("failed to install Go: %w", err)
 	}
 
 	logger.Debug("Go installation completed successfully")
 
+	err = timeStage(StageVerify, func() error {
+		err := verify.InstallationContext(ctx, installDir, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		return verify.VerifyLayout(installDir)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to verify installation: %w", err)
+	}
+
+	logger.Debug("verify.Installation succeeded")
+
+	return nil
+}
+
+// backupInstallation moves the installation at installDir to backupDir, replacing any stale backup
+// left behind by an earlier interrupted update.
+func backupInstallation(installDir, backupDir string) error {
+	err := os.RemoveAll(backupDir)
+	if err != nil {
+		return fmt.Errorf("failed to remove stale backup %s: %w", backupDir, err)
+	}
+
+	err = os.Rename(installDir, backupDir)
+	if err != nil {
+		return fmt.Errorf("failed to move %s to %s: %w", installDir, backupDir, err)
+	}
+
+	return nil
+}
+
+// restoreInstallation discards whatever was installed at installDir and moves the backup back into place.
+func restoreInstallation(backupDir, installDir string) error {
+	logger.Debugf("Restoring previous Go installation from %s", backupDir)
+
+	err := os.RemoveAll(installDir)
+	if err != nil {
+		return fmt.Errorf("failed to remove failed installation; previous Go is preserved at %s: %w", backupDir, err)
+	}
+
+	err = os.Rename(backupDir, installDir)
+	if err != nil {
+		return fmt.Errorf("failed to restore previous Go; it is preserved at %s: %w", backupDir, err)
+	}
+
 	return nil
 }
 
+// BackupVersion returns the Go version of the backup that Rollback would restore for installDir,
+// or ErrNoBackup if there is none.
+func BackupVersion(installDir string) (string, error) {
+	backupDir := installDir + backupSuffix
+
+	_, err := os.Stat(backupDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%s does not exist (updates keep it only with --keep-backup): %w", backupDir, ErrNoBackup)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to stat %s: %w", backupDir, err)
+	}
+
+	backupVersion, err := verify.GetInstalledVersion(backupDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine the Go version in %s: %w", backupDir, err)
+	}
+
+	return backupVersion, nil
+}
+
+// Rollback restores the Go installation that the last update replaced, from installDir+backupSuffix,
+// and verifies it. The installation it replaces becomes the new backup, so a second Rollback undoes the first.
+// If the restored installation cannot be verified, both installations are swapped back.
+// It returns the restored version, or ErrNoBackup if there is nothing to roll back to.
+func Rollback(installDir string) (string, error) {
+	backupDir := installDir + backupSuffix
+
+	backupVersion, err := BackupVersion(installDir)
+	if err != nil {
+		return "", err
+	}
+
+	logger.Debugf("Rolling back %s to %s from %s", installDir, backupVersion, backupDir)
+
+	currentDir := installDir + rollbackSuffix
+
+	err = os.RemoveAll(currentDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to remove stale %s: %w", currentDir, err)
+	}
+
+	err = os.Rename(installDir, currentDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to move %s aside: %w", installDir, err)
+	}
+
+	hadCurrent := err == nil
+
+	err = os.Rename(backupDir, installDir)
+	if err == nil {
+		err = verify.Installation(installDir, backupVersion)
+		if err != nil {
+			_ = os.Rename(installDir, backupDir)
+		}
+	}
+
+	if err != nil {
+		if hadCurrent {
+			_ = os.Rename(currentDir, installDir)
+		}
+
+		return "", fmt.Errorf("failed to restore %s: %w", backupDir, err)
+	}
+
+	if hadCurrent {
+		err = os.Rename(currentDir, backupDir)
+		if err != nil {
+			logger.Warnf("Failed to keep the replaced installation as a backup at %s: %v", backupDir, err)
+		}
+	}
+
+	return backupVersion, nil
+}
+
+// updateAction decides what an update from installedVersion to targetVersion does, for both goVersion and
+// PlanUpdate: ActionInstalled when Go is not installed, ActionUpdated when the target is newer, or with
+// allowDowngrade merely different, and otherwise ActionSkipped, unless SetForce requests a reinstall.
+func updateAction(installedVersion, targetVersion string, allowDowngrade bool) Action {
+	var change bool
+	if allowDowngrade {
+		change = needsVersionChange(installedVersion, targetVersion)
+	} else {
+		change = needsUpdate(installedVersion, targetVersion)
+	}
+
+	logger.Debugf("needsUpdate result: %t", change)
+
+	switch {
+	case change && installedVersion == "":
+		return ActionInstalled
+	case change:
+		return ActionUpdated
+	case forced():
+		logger.Infof("Forcing a reinstall of Go %s", targetVersion)
+
+		return reinstallAction(installedVersion, targetVersion)
+	default:
+		return ActionSkipped
+	}
+}
+
+// reinstallAction returns the action of a forced update from installedVersion to targetVersion:
+// ActionReinstalled for the same release, or ActionUpdated when the installed version is newer.
+func reinstallAction(installedVersion, targetVersion string) Action {
+	if download.CompareGoVersions(installedVersion, targetVersion) == 0 {
+		return ActionReinstalled
+	}
+
+	return ActionUpdated
+}
+
+// needsVersionChange reports whether installedVersion differs from the target version, in either direction.
+func needsVersionChange(installedVersion, targetVersion string) bool {
+	if download.CompareGoVersions(installedVersion, targetVersion) == 0 {
+		logger.Debugf("Go version %s already installed.", targetVersion)
+
+		return false
+	}
+
+	if installedVersion != "" {
+		logger.Infof("Changing Go from %s to %s", installedVersion, targetVersion)
+	}
+
+	return true
+}
+
 // needsUpdate determines if an update is required based on version comparison.
 func needsUpdate(installedVersion, latestVersionStr string) bool {
 	if installedVersion == "" {
 		return true
 	}
 
-	if version.Compare(installedVersion, latestVersionStr) >= 0 {
-		logger.Infof("Latest Go version (%s) already installed.", latestVersionStr)
+	if download.CompareGoVersions(installedVersion, latestVersionStr) >= 0 {
+		logger.Debugf("Latest Go version (%s) already installed.", latestVersionStr)
 
 		return false
 	}
@@ -4,12 +4,24 @@
 package update
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/nicholas-fedor/goUpdater/internal/uninstall"
 )
 
 func TestGo(t *testing.T) {
@@ -88,6 +100,11 @@ func runGoTests(t *testing.T, testCases []struct {
 			}
 
 			err := Go(installDir, testCase.autoInstall)
+			if errors.Is(err, ErrAlreadyUpToDate) {
+				// Already being up to date is a successful no-op
+				err = nil
+			}
+
 			if (err != nil) != testCase.wantErr {
 				t.Errorf("Go() error = %v, wantErr %v", err, testCase.wantErr)
 			}
@@ -198,6 +215,10 @@ func TestGoWithPrivileges(t *testing.T) {
 			}
 
 			err := GoWithPrivileges(installDir, testCase.autoInstall)
+			if errors.Is(err, ErrAlreadyUpToDate) {
+				err = nil
+			}
+
 			if (err != nil) != testCase.wantErr {
 				t.Errorf("GoWithPrivileges() error = %v, wantErr %v", err, testCase.wantErr)
 			}
@@ -239,9 +260,9 @@ func TestNeedsUpdate(t *testing.T) {
 
 		// This should not perform an update since installed version is newer
 		err = Go(installDir, false)
-		// We expect this to succeed (no error) because no update is needed
-		if err != nil {
-			t.Errorf("Expected no error when no update is needed, but got: %v", err)
+		// We expect ErrAlreadyUpToDate because no update is needed
+		if !errors.Is(err, ErrAlreadyUpToDate) {
+			t.Errorf("Expected ErrAlreadyUpToDate when no update is needed, but got: %v", err)
 		}
 	})
 
@@ -385,6 +406,16 @@ func TestErrGoNotInstalled(t *testing.T) {
 	}
 }
 
+// TestErrAlreadyUpToDate verifies the exported error variable.
+func TestErrAlreadyUpToDate(t *testing.T) {
+	t.Parallel()
+
+	expectedMsg := "Go is already up to date"
+	if ErrAlreadyUpToDate.Error() != expectedMsg {
+		t.Errorf("ErrAlreadyUpToDate.Error() = %v, want %v", ErrAlreadyUpToDate.Error(), expectedMsg)
+	}
+}
+
 // TestCheckInstallation tests the checkInstallation function indirectly through Go.
 func TestCheckInstallation(t *testing.T) {
 	t.Parallel()
@@ -425,11 +456,138 @@ func TestCheckInstallation(t *testing.T) {
 	})
 }
 
-// TestDownloadLatest tests the downloadLatest function indirectly.
+// TestCheckInstallationBroken is not parallel because one case uses SetForce, which changes package-level state.
+func TestCheckInstallationBroken(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake go binary is a shell script")
+	}
+
+	t.Cleanup(func() { SetForce(false) })
+
+	tests := []struct {
+		name        string
+		setup       func(t *testing.T, installDir string)
+		autoInstall bool
+		force       bool
+		wantVersion string
+		wantErr     error
+		wantMessage string
+	}{
+		{
+			name:        "working installation",
+			setup:       func(t *testing.T, installDir string) { t.Helper(); writeFakeGo(t, installDir, "go1.21.0") },
+			autoInstall: false, force: false, wantVersion: "go1.21.0", wantErr: nil, wantMessage: "",
+		},
+		{
+			name:        "unparseable version",
+			setup:       writeBrokenGo,
+			autoInstall: false, force: false, wantVersion: "", wantErr: ErrBrokenInstallation, wantMessage: "",
+		},
+		{
+			name:        "unparseable version with auto install",
+			setup:       writeBrokenGo,
+			autoInstall: true, force: false, wantVersion: "", wantErr: nil, wantMessage: "",
+		},
+		{
+			name:        "unparseable version with force",
+			setup:       writeBrokenGo,
+			autoInstall: false, force: true, wantVersion: "", wantErr: nil, wantMessage: "",
+		},
+		{
+			name: "VERSION file without a go binary",
+			setup: func(t *testing.T, installDir string) {
+				t.Helper()
+
+				err := os.MkdirAll(installDir, 0700)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				err = os.WriteFile(filepath.Join(installDir, "VERSION"), []byte("go1.21.0\ntime 2023-08-08\n"), 0600)
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			autoInstall: false, force: false, wantVersion: "", wantErr: ErrBrokenInstallation,
+			wantMessage: "partial go1.21.0 installation",
+		},
+		{
+			name:        "missing directory",
+			setup:       func(*testing.T, string) {},
+			autoInstall: false, force: false, wantVersion: "", wantErr: ErrGoNotInstalled, wantMessage: "",
+		},
+		{
+			name:        "non-Go directory with auto install",
+			setup:       writeUnrelatedDir,
+			autoInstall: true, force: false, wantVersion: "", wantErr: uninstall.ErrNotGoInstallation, wantMessage: "",
+		},
+		{
+			name:        "non-Go directory with force",
+			setup:       writeUnrelatedDir,
+			autoInstall: false, force: true, wantVersion: "", wantErr: uninstall.ErrNotGoInstallation, wantMessage: "",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			installDir := filepath.Join(t.TempDir(), "go")
+			testCase.setup(t, installDir)
+			SetForce(testCase.force)
+
+			version, err := checkInstallation(context.Background(), installDir, testCase.autoInstall)
+			if !errors.Is(err, testCase.wantErr) || (testCase.wantErr == nil && err != nil) {
+				t.Fatalf("checkInstallation() error = %v, want %v", err, testCase.wantErr)
+			}
+
+			if version != testCase.wantVersion {
+				t.Errorf("checkInstallation() = %q, want %q", version, testCase.wantVersion)
+			}
+
+			if testCase.wantMessage != "" && !strings.Contains(err.Error(), testCase.wantMessage) {
+				t.Errorf("checkInstallation() error = %v, want it to mention %q", err, testCase.wantMessage)
+			}
+		})
+	}
+}
+
+// writeUnrelatedDir creates dir holding a single file and none of the markers of a Go distribution.
+func writeUnrelatedDir(t *testing.T, dir string) {
+	t.Helper()
+
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "precious.txt"), []byte("keep me"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeBrokenGo creates a go binary in dir/bin whose output cannot be parsed, and the src directory of
+// a distribution, as left by a damaged installation.
+func writeBrokenGo(t *testing.T, dir string) {
+	t.Helper()
+
+	for _, sub := range []string{"bin", "src"} {
+		err := os.MkdirAll(filepath.Join(dir, sub), 0700)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := os.WriteFile(filepath.Join(dir, "bin", "go"), []byte("#!/bin/sh\necho garbage\n"), 0755) // #nosec G306
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestDownloadLatest tests the downloadVersion function indirectly.
 func TestDownloadLatest(t *testing.T) {
 	t.Parallel()
 
-	// Since downloadLatest calls external services, we test through the main function
+	// Since downloadVersion calls external services, we test through the main function
 	// In a real scenario, we'd mock the download package
 	t.Run("download latest integration", func(t *testing.T) {
 		t.Parallel()
@@ -522,3 +680,618 @@ func TestCheckAndPrepare(t *testing.T) {
 		_ = err // May fail at download step
 	})
 }
+
+// writeGoArchive writes a gzipped tarball containing a go/bin/go script that reports goVersion.
+func writeGoArchive(t *testing.T, path, goVersion string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	script := []byte("#!/bin/sh\necho 'go version " + goVersion + " linux/amd64'\n")
+
+	versionFile := []byte(goVersion + "\ntime 2023-08-08T00:00:00Z\n")
+
+	// Besides bin/go, the entries verify.VerifyLayout requires
+	for _, entry := range []struct {
+		header  *tar.Header
+		content []byte
+	}{
+		{header: &tar.Header{Name: "go/", Typeflag: tar.TypeDir, Mode: 0755}, content: nil},
+		{header: &tar.Header{Name: "go/bin/", Typeflag: tar.TypeDir, Mode: 0755}, content: nil},
+		{header: &tar.Header{Name: "go/bin/go", Typeflag: tar.TypeReg, Mode: 0755}, content: script},
+		{header: &tar.Header{Name: "go/bin/gofmt", Typeflag: tar.TypeReg, Mode: 0755}, content: script},
+		{header: &tar.Header{Name: "go/pkg/", Typeflag: tar.TypeDir, Mode: 0755}, content: nil},
+		{header: &tar.Header{Name: "go/src/", Typeflag: tar.TypeDir, Mode: 0755}, content: nil},
+		{header: &tar.Header{Name: "go/VERSION", Typeflag: tar.TypeReg, Mode: 0644}, content: versionFile},
+	} {
+		entry.header.Size = int64(len(entry.content))
+
+		err := tarWriter.WriteHeader(entry.header)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = tarWriter.Write(entry.content)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := tarWriter.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(path, buf.Bytes(), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPerformUpdateRollback(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		archiveVersion string
+		expectedOutput string
+		wantErr        bool
+	}{
+		{name: "verified update removes backup", archiveVersion: "go1.21.0", expectedOutput: "go1.21.0",
+			wantErr: false},
+		{name: "failed verification restores previous", archiveVersion: "go1.99.0", expectedOutput: "go1.20.0",
+			wantErr: true},
+		{name: "missing archive restores previous", archiveVersion: "", expectedOutput: "go1.20.0", wantErr: true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			installDir := filepath.Join(tempDir, "go")
+			archivePath := filepath.Join(tempDir, "go.tar.gz")
+
+			err := os.MkdirAll(filepath.Join(installDir, "bin"), 0700)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = os.WriteFile(filepath.Join(installDir, "bin", "go"),
+				[]byte("#!/bin/sh\necho 'go version go1.20.0 linux/amd64'\n"), 0755) // #nosec G306
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if testCase.archiveVersion != "" {
+				writeGoArchive(t, archivePath, testCase.archiveVersion)
+			}
+
+			err = performUpdate(context.Background(), archivePath, installDir, "go1.20.0", "go1.21.0")
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("performUpdate() error = %v, wantErr %t", err, testCase.wantErr)
+			}
+
+			output, err := exec.CommandContext(t.Context(), filepath.Join(installDir, "bin", "go"), "version").Output()
+			if err != nil {
+				t.Fatalf("installed go binary failed: %v", err)
+			}
+
+			if !strings.Contains(string(output), testCase.expectedOutput) {
+				t.Errorf("installed go reports %q, want %s", output, testCase.expectedOutput)
+			}
+
+			_, err = os.Stat(installDir + backupSuffix)
+			if !os.IsNotExist(err) {
+				t.Errorf("expected backup to be gone, got %v", err)
+			}
+		})
+	}
+}
+
+// TestPerformUpdateKeepBackup is not parallel because SetKeepBackup changes package-level state.
+func TestPerformUpdateKeepBackup(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake go binary is a shell script")
+	}
+
+	SetKeepBackup(true)
+
+	t.Cleanup(func() { SetKeepBackup(false) })
+
+	tempDir := t.TempDir()
+	installDir := filepath.Join(tempDir, "go")
+	archivePath := filepath.Join(tempDir, "go.tar.gz")
+
+	writeFakeGo(t, installDir, "go1.20.0")
+	writeGoArchive(t, archivePath, "go1.21.0")
+
+	err := performUpdate(context.Background(), archivePath, installDir, "go1.20.0", "go1.21.0")
+	if err != nil {
+		t.Fatalf("performUpdate() error = %v", err)
+	}
+
+	backupVersion, err := BackupVersion(installDir)
+	if err != nil || backupVersion != "go1.20.0" {
+		t.Errorf("BackupVersion() = %q, %v, want the kept go1.20.0", backupVersion, err)
+	}
+}
+
+func TestPerformUpdateRestoresBrokenInstallation(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake go binary is a shell script")
+	}
+
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	installDir := filepath.Join(tempDir, "go")
+	archivePath := filepath.Join(tempDir, "go.tar.gz")
+
+	writeBrokenGo(t, installDir)
+	writeGoArchive(t, archivePath, "go1.99.0")
+
+	// A broken installation has no version, but it is backed up and restored when the update fails.
+	err := performUpdate(context.Background(), archivePath, installDir, "", "go1.21.0")
+	if err == nil {
+		t.Fatal("performUpdate() succeeded, want a verification failure")
+	}
+
+	content, err := os.ReadFile(filepath.Join(installDir, "bin", "go"))
+	if err != nil || !strings.Contains(string(content), "garbage") {
+		t.Errorf("broken installation was not restored: %q, %v", content, err)
+	}
+}
+
+// TestPerformUpdateStageTimer is not parallel because SetStageTimer changes package-level state.
+func TestPerformUpdateStageTimer(t *testing.T) {
+	tempDir := t.TempDir()
+	installDir := filepath.Join(tempDir, "go")
+	archivePath := filepath.Join(tempDir, "go.tar.gz")
+
+	writeFakeGo(t, installDir, "go1.20.0")
+	writeGoArchive(t, archivePath, "go1.21.0")
+
+	var stages []Stage
+
+	SetStageTimer(func(stage Stage, duration time.Duration) {
+		if duration < 0 {
+			t.Errorf("stage %s reported a negative duration %s", stage, duration)
+		}
+
+		stages = append(stages, stage)
+	})
+	t.Cleanup(func() { SetStageTimer(nil) })
+
+	err := performUpdate(context.Background(), archivePath, installDir, "go1.20.0", "go1.21.0")
+	if err != nil {
+		t.Fatalf("performUpdate() error = %v", err)
+	}
+
+	want := []Stage{StageUninstall, StageInstall, StageVerify}
+	if !slices.Equal(stages, want) {
+		t.Errorf("reported stages = %v, want %v", stages, want)
+	}
+}
+
+func TestPerformUpdateCancelled(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	installDir := filepath.Join(tempDir, "go")
+	archivePath := filepath.Join(tempDir, "go.tar.gz")
+
+	writeFakeGo(t, installDir, "go1.20.0")
+	writeGoArchive(t, archivePath, "go1.21.0")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := performUpdate(ctx, archivePath, installDir, "go1.20.0", "go1.21.0")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("performUpdate() error = %v, want %v", err, context.Canceled)
+	}
+
+	output, err := exec.CommandContext(t.Context(), filepath.Join(installDir, "bin", "go"), "version").Output()
+	if err != nil {
+		t.Fatalf("installed go binary failed: %v", err)
+	}
+
+	if !strings.Contains(string(output), "go1.20.0") {
+		t.Errorf("installed version = %q, want the previous go1.20.0 left in place", output)
+	}
+
+	_, err = os.Stat(installDir + backupSuffix)
+	if !os.IsNotExist(err) {
+		t.Errorf("expected no backup after a cancelled update, got %v", err)
+	}
+}
+
+// writeFakeGo creates a go binary in dir/bin that reports the given version.
+func writeFakeGo(t *testing.T, dir, goVersion string) {
+	t.Helper()
+
+	err := os.MkdirAll(filepath.Join(dir, "bin"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "bin", "go"),
+		[]byte("#!/bin/sh\necho 'go version "+goVersion+" linux/amd64'\n"), 0755) // #nosec G306
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	t.Parallel()
+
+	installDir := filepath.Join(t.TempDir(), "go")
+
+	_, err := Rollback(installDir)
+	if !errors.Is(err, ErrNoBackup) {
+		t.Fatalf("Rollback() without backup error = %v, want %v", err, ErrNoBackup)
+	}
+
+	writeFakeGo(t, installDir, "go1.21.0")
+	writeFakeGo(t, installDir+backupSuffix, "go1.20.0")
+
+	restored, err := Rollback(installDir)
+	if err != nil {
+		t.Fatalf("Rollback() unexpected error: %v", err)
+	}
+
+	if restored != "go1.20.0" {
+		t.Errorf("Rollback() = %s, want go1.20.0", restored)
+	}
+
+	for dir, want := range map[string]string{installDir: "go1.20.0", installDir + backupSuffix: "go1.21.0"} {
+		output, err := exec.CommandContext(t.Context(), filepath.Join(dir, "bin", "go"), "version").Output()
+		if err != nil {
+			t.Fatalf("go binary in %s failed: %v", dir, err)
+		}
+
+		if !strings.Contains(string(output), want) {
+			t.Errorf("go in %s reports %q, want %s", dir, output, want)
+		}
+	}
+}
+
+func TestNeedsVersionChange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		installedVersion string
+		targetVersion    string
+		expected         bool
+	}{
+		{name: "not installed", installedVersion: "", targetVersion: "1.21.13", expected: true},
+		{name: "same version", installedVersion: "go1.21.13", targetVersion: "1.21.13", expected: false},
+		{name: "patch component omitted", installedVersion: "go1.21", targetVersion: "1.21.0", expected: false},
+		{name: "downgrade", installedVersion: "go1.22.0", targetVersion: "1.21.13", expected: true},
+		{name: "upgrade", installedVersion: "go1.21.0", targetVersion: "1.21.13", expected: true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := needsVersionChange(testCase.installedVersion, testCase.targetVersion); got != testCase.expected {
+				t.Errorf("needsVersionChange(%q, %q) = %t, want %t",
+					testCase.installedVersion, testCase.targetVersion, got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestReinstallAction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		installedVersion string
+		targetVersion    string
+		expected         Action
+	}{
+		{name: "same version", installedVersion: "go1.21.13", targetVersion: "1.21.13", expected: ActionReinstalled},
+		{name: "patch component omitted", installedVersion: "go1.21", targetVersion: "1.21.0",
+			expected: ActionReinstalled},
+		{name: "installed is newer", installedVersion: "go1.22.0", targetVersion: "1.21.13", expected: ActionUpdated},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := reinstallAction(testCase.installedVersion, testCase.targetVersion); got != testCase.expected {
+				t.Errorf("reinstallAction(%q, %q) = %q, want %q",
+					testCase.installedVersion, testCase.targetVersion, got, testCase.expected)
+			}
+		})
+	}
+}
+
+// TestSetForce is not parallel because SetForce changes package-level state.
+func TestSetForce(t *testing.T) {
+	t.Cleanup(func() { SetForce(false) })
+
+	SetForce(true)
+
+	if !forced() {
+		t.Error("forced() = false after SetForce(true)")
+	}
+
+	SetForce(false)
+
+	if forced() {
+		t.Error("forced() = true after SetForce(false)")
+	}
+}
+
+// TestUpdateAction is not parallel because the forced cases change package-level state with SetForce.
+func TestUpdateAction(t *testing.T) {
+	t.Cleanup(func() { SetForce(false) })
+
+	tests := []struct {
+		name             string
+		installedVersion string
+		targetVersion    string
+		allowDowngrade   bool
+		force            bool
+		expected         Action
+	}{
+		{
+			name: "not installed", installedVersion: "", targetVersion: "1.22.0",
+			allowDowngrade: false, force: false, expected: ActionInstalled,
+		},
+		{
+			name: "newer target", installedVersion: "go1.21.0", targetVersion: "1.22.0",
+			allowDowngrade: false, force: false, expected: ActionUpdated,
+		},
+		{
+			name: "same version", installedVersion: "go1.22.0", targetVersion: "1.22.0",
+			allowDowngrade: false, force: false, expected: ActionSkipped,
+		},
+		{
+			name: "older target", installedVersion: "go1.22.0", targetVersion: "1.21.0",
+			allowDowngrade: false, force: false, expected: ActionSkipped,
+		},
+		{
+			name: "older target with downgrade", installedVersion: "go1.22.0", targetVersion: "1.21.0",
+			allowDowngrade: true, force: false, expected: ActionUpdated,
+		},
+		{
+			name: "same version forced", installedVersion: "go1.22.0", targetVersion: "1.22.0",
+			allowDowngrade: false, force: true, expected: ActionReinstalled,
+		},
+		{
+			name: "newer target forced", installedVersion: "go1.21.0", targetVersion: "1.22.0",
+			allowDowngrade: false, force: true, expected: ActionUpdated,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			SetForce(testCase.force)
+
+			got := updateAction(testCase.installedVersion, testCase.targetVersion, testCase.allowDowngrade)
+			if got != testCase.expected {
+				t.Errorf("updateAction(%q, %q, %t) = %q, want %q", testCase.installedVersion,
+					testCase.targetVersion, testCase.allowDowngrade, got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestNeedsUpdateVersionOrder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		installedVersion string
+		latestVersion    string
+		expected         bool
+	}{
+		{name: "not installed", installedVersion: "", latestVersion: "1.21.0", expected: true},
+		{name: "two-digit minor is newer", installedVersion: "go1.9.1", latestVersion: "1.21.0", expected: true},
+		{name: "installed is newer", installedVersion: "go1.21.0", latestVersion: "1.9.1", expected: false},
+		{name: "same version", installedVersion: "go1.21.13", latestVersion: "1.21.13", expected: false},
+		{name: "patch component omitted", installedVersion: "go1.21", latestVersion: "1.21.0", expected: false},
+		{name: "release candidate", installedVersion: "go1.24rc1", latestVersion: "1.24.0", expected: true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := needsUpdate(testCase.installedVersion, testCase.latestVersion); got != testCase.expected {
+				t.Errorf("needsUpdate(%q, %q) = %t, want %t",
+					testCase.installedVersion, testCase.latestVersion, got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestToVersionRequiresVersion(t *testing.T) {
+	t.Parallel()
+
+	err := ToVersion(t.TempDir(), "", true)
+	if !errors.Is(err, ErrVersionRequired) {
+		t.Errorf("ToVersion() error = %v, want %v", err, ErrVersionRequired)
+	}
+}
+
+func TestSkippedAsError(t *testing.T) {
+	t.Parallel()
+
+	failure := errors.New("download failed")
+
+	tests := []struct {
+		name    string
+		report  *Report
+		err     error
+		wantErr error
+	}{
+		{
+			name:    "skipped",
+			report:  &Report{FromVersion: "go1.21.0", ToVersion: "go1.21.0", Action: ActionSkipped, DurationMs: 1},
+			err:     nil,
+			wantErr: ErrAlreadyUpToDate,
+		},
+		{
+			name:    "updated",
+			report:  &Report{FromVersion: "go1.20.0", ToVersion: "go1.21.0", Action: ActionUpdated, DurationMs: 1},
+			err:     nil,
+			wantErr: nil,
+		},
+		{name: "failed", report: nil, err: failure, wantErr: failure},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := skippedAsError(testCase.report, testCase.err)
+			if !errors.Is(err, testCase.wantErr) || (testCase.wantErr == nil && err != nil) {
+				t.Errorf("skippedAsError() error = %v, want %v", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckForUpdate(t *testing.T) {
+	// Subtests use t.Setenv() which cannot be used with parallel tests
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte(`[{"version": "go1.21.0", "stable": true, "files": []}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name             string
+		installedVersion string
+		expected         bool
+	}{
+		{name: "older installed", installedVersion: "go1.20.0", expected: true},
+		{name: "latest installed", installedVersion: "go1.21.0", expected: false},
+		{name: "not installed", installedVersion: "", expected: true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv("GO_UPDATER_BASE_URL", server.URL)
+
+			installDir := filepath.Join(t.TempDir(), "go")
+
+			if testCase.installedVersion != "" {
+				err := os.MkdirAll(filepath.Join(installDir, "bin"), 0700)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				err = os.WriteFile(filepath.Join(installDir, "bin", "go"), // #nosec G306
+					[]byte("#!/bin/sh\necho 'go version "+testCase.installedVersion+" linux/amd64'\n"), 0755)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			availability, err := CheckForUpdate(installDir)
+			if err != nil {
+				t.Fatalf("CheckForUpdate() error = %v", err)
+			}
+
+			if availability.Installed != testCase.installedVersion || availability.Latest != "go1.21.0" {
+				t.Errorf("CheckForUpdate() = %+v, want installed %q and latest go1.21.0",
+					availability, testCase.installedVersion)
+			}
+
+			if availability.UpdateAvailable != testCase.expected {
+				t.Errorf("UpdateAvailable = %t, want %t", availability.UpdateAvailable, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestPlanUpdate(t *testing.T) {
+	// Subtests use t.Setenv() which cannot be used with parallel tests
+	archive := fmt.Sprintf("go1.21.0.%s-%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+	feed := fmt.Sprintf(`[{"version": "go1.21.0", "stable": true, "files": [`+
+		`{"filename": %q, "os": %q, "arch": %q, "kind": "archive", "size": 1024}]}]`,
+		archive, runtime.GOOS, runtime.GOARCH)
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte(feed))
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name             string
+		installedVersion string
+		expectedAction   Action
+		expectedSize     int64
+	}{
+		{name: "older installed", installedVersion: "go1.20.0", expectedAction: ActionUpdated, expectedSize: 1024},
+		{name: "latest installed", installedVersion: "go1.21.0", expectedAction: ActionSkipped, expectedSize: 0},
+		{name: "not installed", installedVersion: "", expectedAction: ActionInstalled, expectedSize: 1024},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv("GO_UPDATER_BASE_URL", server.URL)
+
+			installDir := filepath.Join(t.TempDir(), "go")
+			if testCase.installedVersion != "" {
+				writeFakeGo(t, installDir, testCase.installedVersion)
+			}
+
+			plan, err := PlanUpdate(installDir, "", true)
+			if err != nil {
+				t.Fatalf("PlanUpdate() error = %v", err)
+			}
+
+			if plan.Action != testCase.expectedAction || plan.DownloadSize != testCase.expectedSize {
+				t.Errorf("PlanUpdate() = %+v, want action %s and size %d",
+					plan, testCase.expectedAction, testCase.expectedSize)
+			}
+
+			if plan.FromVersion != testCase.installedVersion || plan.ToVersion != "go1.21.0" {
+				t.Errorf("PlanUpdate() = %+v, want %q -> go1.21.0", plan, testCase.installedVersion)
+			}
+
+			_, err = os.Stat(installDir)
+			if testCase.installedVersion == "" && !os.IsNotExist(err) {
+				t.Errorf("PlanUpdate() created %s", installDir)
+			}
+		})
+	}
+}
+
+func TestUpdateAllContinuesPastFailures(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	installDirs := []string{filepath.Join(tempDir, "first"), filepath.Join(tempDir, "second")}
+
+	reports, err := UpdateAll(installDirs, false)
+	if !errors.Is(err, ErrGoNotInstalled) {
+		t.Fatalf("UpdateAll() error = %v, want %v", err, ErrGoNotInstalled)
+	}
+
+	for _, installDir := range installDirs {
+		if !strings.Contains(err.Error(), installDir) {
+			t.Errorf("UpdateAll() error %q does not mention %s", err, installDir)
+		}
+	}
+
+	if len(reports) != 0 {
+		t.Errorf("UpdateAll() returned %d reports, want 0", len(reports))
+	}
+}
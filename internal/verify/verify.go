@@ -6,24 +6,74 @@
 package verify
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"syscall"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/nicholas-fedor/goUpdater/internal/cli"
+	"github.com/nicholas-fedor/goUpdater/internal/download"
 	"github.com/nicholas-fedor/goUpdater/internal/logger"
 )
 
+const testProgramPerm = 0600 // File permissions for the deep check test program
+
+// develPrefix starts the first line of a development build's VERSION file.
+const develPrefix = "devel"
+
 // errVersionMismatch indicates a version mismatch.
 var errVersionMismatch = errors.New("version mismatch")
 
 // errVersionParseError indicates an error parsing the version.
 var errVersionParseError = errors.New("version parse error")
 
+// errDeepCheckFailed indicates one or more deep verification checks failed.
+var errDeepCheckFailed = errors.New("deep verification failed")
+
+// errToolMissing indicates a required toolchain binary is missing from pkg/tool.
+var errToolMissing = errors.New("toolchain binary missing")
+
+// ErrArchitectureMismatch indicates an installed go binary that the host cannot execute,
+// typically because the archive was built for another architecture or operating system.
+var ErrArchitectureMismatch = errors.New("go binary cannot run on this platform")
+
+// ErrPlatformMismatch indicates an installed Go toolchain whose GOOS or GOARCH differs from the host's.
+var ErrPlatformMismatch = errors.New("go toolchain targets a different platform")
+
+// ErrIncompleteInstallation indicates an installation missing entries that every Go distribution contains,
+// such as one extracted from a truncated archive.
+var ErrIncompleteInstallation = errors.New("incomplete Go installation")
+
+// ErrSignatureInvalid indicates an archive's detached OpenPGP signature did not verify against the public key.
+var ErrSignatureInvalid = errors.New("invalid archive signature")
+
+// layoutEntries lists the slash-separated paths, relative to the installation directory, that VerifyLayout
+// requires. The bin entries gain an .exe suffix on Windows.
+//
+//nolint:gochecknoglobals
+var layoutEntries = []string{"bin/go", "bin/gofmt", "pkg", "src", "VERSION"}
+
+// requiredTools lists the pkg/tool binaries needed to build a trivial program.
+//
+//nolint:gochecknoglobals
+var requiredTools = []string{"asm", "compile", "link"}
+
+// CheckResult holds the outcome of a single deep verification check.
+type CheckResult struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+}
+
 // VerificationInfo holds detailed verification information for the Go installation.
 // It includes the installation directory, version, and verification status.
 //
@@ -35,8 +85,14 @@ type VerificationInfo struct {
 }
 
 // Installation checks if Go is properly installed and matches the expected version.
-// It verifies that the go binary exists and that 'go version' returns the expected version.
+// It verifies that the go binary exists and that 'go version' reports the same release as expectedVersion,
+// such as go1.21.0, with or without the "go" prefix and a zero patch component.
 func Installation(installDir, expectedVersion string) error {
+	return InstallationContext(context.Background(), installDir, expectedVersion)
+}
+
+// InstallationContext behaves like Installation, but kills the go commands it runs once ctx is done.
+func InstallationContext(ctx context.Context, installDir, expectedVersion string) error {
 	logger.Debugf("Verifying Go installation: installDir=%s, expectedVersion=%s", installDir, expectedVersion)
 	goBinary := filepath.Join(installDir, "bin", "go")
 
@@ -53,30 +109,119 @@ func Installation(installDir, expectedVersion string) error {
 	// gosec: G204 - Subprocess launched with variable is acceptable here as we control the goBinary path
 	logger.Debug("Running 'go version' command")
 
-	cmd := exec.CommandContext(context.Background(), goBinary, "version") //nolint:gosec
+	cmd := exec.CommandContext(ctx, goBinary, "version") //nolint:gosec
 
 	output, err := cmd.Output()
 	if err != nil {
-		return fmt.Errorf("failed to run 'go version': %w", err)
+		return goVersionError(goBinary, err)
 	}
 
 	versionOutput := strings.TrimSpace(string(output))
 	logger.Debugf("Go version output: %s", versionOutput)
 
-	// Check if the output contains the expected version
-	if !strings.Contains(versionOutput, expectedVersion) {
+	// Compare whole releases, so that go1.21.10 does not pass for go1.21.1, while go1.21 does for go1.21.0
+	if !sameRelease(versionOutput, expectedVersion) {
 		return fmt.Errorf("version mismatch: expected %s, got %s: %w", expectedVersion, versionOutput, errVersionMismatch)
 	}
 
+	platform, err := checkPlatform(ctx, installDir)
+	if errors.Is(err, ErrPlatformMismatch) {
+		return err
+	}
+
+	if err != nil {
+		logger.Debugf("Skipping the platform check: %v", err)
+	} else {
+		logger.Debugf("Go toolchain platform: %s", platform)
+	}
+
 	logger.Debug("Installation verification successful")
 
 	return nil
 }
 
+// VerifyLayout checks that installDir contains the entries every Go distribution has at its top level:
+// bin/go, bin/gofmt, pkg, src, and VERSION. A truncated archive can leave a go binary that runs while
+// the standard library or toolchain is missing, which the version check alone does not catch.
+// It returns an error wrapping ErrIncompleteInstallation that lists every missing entry.
+func VerifyLayout(installDir string) error {
+	var missing []string
+
+	for _, entry := range layoutEntries {
+		if strings.HasPrefix(entry, "bin/") && runtime.GOOS == "windows" {
+			entry += ".exe"
+		}
+
+		_, err := os.Stat(filepath.Join(installDir, filepath.FromSlash(entry)))
+		if err != nil {
+			logger.Debugf("Layout entry %s is not usable: %v", entry, err)
+
+			missing = append(missing, entry)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w in %s: missing %s", ErrIncompleteInstallation, installDir, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// sameRelease reports whether versionOutput, the output of 'go version', names expectedVersion once both
+// are normalized to the goX.Y.Z form.
+func sameRelease(versionOutput, expectedVersion string) bool {
+	fields := strings.Fields(versionOutput)
+	if len(fields) < 3 {
+		return false
+	}
+
+	return download.NormalizeGoVersion(fields[2]) == download.NormalizeGoVersion(expectedVersion)
+}
+
+// DeepCheck performs a deeper verification of the Go toolchain in installDir.
+// Beyond the version check, it inspects the binary's build information with 'go version -m',
+// confirms the toolchain targets the host platform and that the pkg/tool binaries reported by
+// 'go env GOTOOLDIR' are present, and compiles a trivial program. It returns the result of every check and an error if any of them failed.
+func DeepCheck(installDir string) ([]CheckResult, error) {
+	logger.Debugf("Starting deep verification: installDir=%s", installDir)
+
+	goBinary := filepath.Join(installDir, "bin", "go")
+
+	results := []CheckResult{
+		runCheck("version", func() (string, error) { return GetInstalledVersion(installDir) }),
+		runCheck("layout", func() (string, error) { return "all expected entries present", VerifyLayout(installDir) }),
+		runCheck("build info", func() (string, error) { return checkBuildInfo(goBinary) }),
+		runCheck("platform", func() (string, error) { return CheckPlatform(installDir) }),
+		runCheck("toolchain", func() (string, error) { return checkToolchain(goBinary) }),
+		runCheck("compile", func() (string, error) { return checkCompile(goBinary) }),
+	}
+
+	var failed []string
+
+	for _, result := range results {
+		if !result.Passed {
+			failed = append(failed, result.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return results, fmt.Errorf("%w: %s", errDeepCheckFailed, strings.Join(failed, ", "))
+	}
+
+	logger.Debug("Deep verification successful")
+
+	return results, nil
+}
+
 // GetInstalledVersion returns the version of the currently installed Go.
 // It runs 'go version' and extracts the version string without logging.
 func GetInstalledVersion(installDir string) (string, error) {
-	return getInstalledVersionCore(installDir)
+	return getInstalledVersionCore(context.Background(), installDir)
+}
+
+// GetInstalledVersionContext behaves like GetInstalledVersion, but kills 'go version' once ctx is done.
+func GetInstalledVersionContext(ctx context.Context, installDir string) (string, error) {
+	return getInstalledVersionCore(ctx, installDir)
 }
 
 // GetVerificationInfo returns comprehensive verification information.
@@ -85,7 +230,7 @@ func GetInstalledVersion(installDir string) (string, error) {
 func GetVerificationInfo(installDir string) (VerificationInfo, error) {
 	logger.Debugf("Getting verification info from: %s", installDir)
 
-	version, err := getInstalledVersionCore(installDir)
+	version, err := getInstalledVersionCore(context.Background(), installDir)
 	if err != nil {
 		return VerificationInfo{
 			InstallDir: installDir,
@@ -111,7 +256,7 @@ func GetInstalledVersionWithLogging(installDir string) (string, error) {
 
 	logger.Debugf("Running 'go version' for binary: %s", goBinary)
 
-	version, err := getInstalledVersionCore(installDir)
+	version, err := getInstalledVersionCore(context.Background(), installDir)
 	if err != nil {
 		return "", err
 	}
@@ -122,10 +267,24 @@ func GetInstalledVersionWithLogging(installDir string) (string, error) {
 	return version, nil
 }
 
+// DeepVerification holds the results of a deep verification of a Go installation.
+type DeepVerification struct {
+	InstallDir string        `json:"installDir"`
+	Checks     []CheckResult `json:"checks"`
+}
+
+// printJSON writes value to stdout as indented JSON, logging any encoding error.
+func printJSON(value any) {
+	err := cli.PrintJSON(os.Stdout, value)
+	if err != nil {
+		logger.Errorf("Error encoding JSON: %v", err)
+	}
+}
+
 // Verify performs the complete Go verification workflow.
 // It retrieves verification information for the specified install directory,
-// displays the results, and handles any errors by logging and exiting.
-func Verify(installDir string) {
+// displays the results as a tree or as JSON, and handles any errors by logging and exiting.
+func Verify(installDir string, jsonOutput bool) {
 	logger.Debugf("Starting verification: installDir=%s", installDir)
 
 	info, err := GetVerificationInfo(installDir)
@@ -136,6 +295,12 @@ func Verify(installDir string) {
 
 	logger.Debugf("Verification completed: version=%s, status=%s", info.Version, info.Status)
 
+	if jsonOutput {
+		printJSON(info)
+
+		return
+	}
+
 	var items []string
 
 	if info.InstallDir != "" {
@@ -153,16 +318,193 @@ func Verify(installDir string) {
 	_, _ = fmt.Fprint(os.Stdout, cli.TreeFormat("Go Installation Verification", items))
 }
 
+// Deep performs the deep Go verification workflow.
+// It runs DeepCheck for the specified install directory, displays each check result
+// as a tree or as JSON, and handles any failures by logging and exiting.
+func Deep(installDir string, jsonOutput bool) {
+	logger.Debugf("Starting deep verification: installDir=%s", installDir)
+
+	results, err := DeepCheck(installDir)
+
+	if jsonOutput {
+		printJSON(DeepVerification{InstallDir: installDir, Checks: results})
+	} else {
+		printDeep(installDir, results)
+	}
+
+	if err != nil {
+		logger.Errorf("Error verifying Go installation: %v", err)
+		os.Exit(1)
+	}
+}
+
+// printDeep displays the deep verification results as a tree.
+func printDeep(installDir string, results []CheckResult) {
+	items := []string{"Directory: " + installDir}
+
+	for _, result := range results {
+		status := "passed"
+		if !result.Passed {
+			status = "failed"
+		}
+
+		items = append(items, fmt.Sprintf("%s: %s (%s)", result.Name, status, result.Detail))
+	}
+
+	_, _ = fmt.Fprint(os.Stdout, cli.TreeFormat("Go Installation Deep Verification", items))
+}
+
+// DiscoverInstallations returns the Go installation directories managed on this machine.
+// It includes primaryDir and every directory under ~/sdk (the location used by golang.org/dl)
+// that contains a go binary. Duplicate paths are removed.
+func DiscoverInstallations(primaryDir string) []string {
+	installDirs := []string{filepath.Clean(primaryDir)}
+	seen := map[string]bool{installDirs[0]: true}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		logger.Debugf("Failed to get home directory for discovery: %v", err)
+
+		return installDirs
+	}
+
+	sdkDir := filepath.Join(home, "sdk")
+
+	entries, err := os.ReadDir(sdkDir)
+	if err != nil {
+		logger.Debugf("No SDK directory found at %s: %v", sdkDir, err)
+
+		return installDirs
+	}
+
+	for _, entry := range entries {
+		candidate := filepath.Join(sdkDir, entry.Name())
+		if !entry.IsDir() || seen[candidate] {
+			continue
+		}
+
+		_, err = os.Stat(filepath.Join(candidate, "bin", "go"))
+		if err != nil {
+			continue
+		}
+
+		logger.Debugf("Discovered Go installation: %s", candidate)
+
+		seen[candidate] = true
+		installDirs = append(installDirs, candidate)
+	}
+
+	return installDirs
+}
+
+// workersMutex protects access to workers.
+var workersMutex sync.Mutex //nolint:gochecknoglobals
+
+// workers is the maximum number of installations verified concurrently; zero means one per CPU.
+var workers int //nolint:gochecknoglobals
+
+// SetWorkers sets the maximum number of installations GetAllVerificationInfo verifies concurrently.
+// Zero or a negative count restores the default of one worker per CPU.
+func SetWorkers(count int) {
+	workersMutex.Lock()
+	defer workersMutex.Unlock()
+
+	workers = max(count, 0)
+}
+
+// workerCount returns the number of workers to use for jobs items.
+func workerCount(jobs int) int {
+	workersMutex.Lock()
+	defer workersMutex.Unlock()
+
+	if workers > 0 {
+		return min(workers, jobs)
+	}
+
+	return min(runtime.NumCPU(), jobs)
+}
+
+// GetAllVerificationInfo verifies every directory in installDirs concurrently.
+// A bounded pool of workers, by default at most one per CPU, runs GetVerificationInfo for each directory.
+// The results are returned in the same order as installDirs.
+func GetAllVerificationInfo(installDirs []string) []VerificationInfo {
+	results := make([]VerificationInfo, len(installDirs))
+	indexes := make(chan int)
+
+	var waitGroup sync.WaitGroup
+
+	for range workerCount(len(installDirs)) {
+		waitGroup.Go(func() {
+			for index := range indexes {
+				info, err := GetVerificationInfo(installDirs[index])
+				if err != nil {
+					logger.Debugf("Verification failed for %s: %v", installDirs[index], err)
+				}
+
+				results[index] = info
+			}
+		})
+	}
+
+	for index := range installDirs {
+		indexes <- index
+	}
+
+	close(indexes)
+	waitGroup.Wait()
+
+	return results
+}
+
+// VerifyAll performs the verification workflow for multiple installations.
+// It verifies each directory concurrently, displays a per-installation pass/fail summary
+// as a tree or as JSON, and exits with an error if any installation failed verification.
+func VerifyAll(installDirs []string, jsonOutput bool) {
+	logger.Debugf("Starting verification of %d installations", len(installDirs))
+
+	results := GetAllVerificationInfo(installDirs)
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].InstallDir < results[j].InstallDir })
+
+	failed := 0
+
+	items := make([]string, 0, len(results))
+
+	for _, info := range results {
+		if info.Status != "verified" {
+			failed++
+		}
+
+		version := info.Version
+		if version == "" {
+			version = "unknown"
+		}
+
+		items = append(items, fmt.Sprintf("%s: %s (%s)", info.InstallDir, version, info.Status))
+	}
+
+	if jsonOutput {
+		printJSON(results)
+	} else {
+		_, _ = fmt.Fprint(os.Stdout, cli.TreeFormat("Go Installations Verification", items))
+	}
+
+	if failed > 0 {
+		logger.Errorf("%d of %d Go installations failed verification", failed, len(results))
+		os.Exit(1)
+	}
+}
+
 // getInstalledVersionCore returns the version of the currently installed Go without logging.
 // It runs 'go version' and extracts the version string.
-func getInstalledVersionCore(installDir string) (string, error) {
+func getInstalledVersionCore(ctx context.Context, installDir string) (string, error) {
 	goBinary := filepath.Join(installDir, "bin", "go")
 
-	cmd := exec.CommandContext(context.Background(), goBinary, "version") //nolint:gosec
+	cmd := exec.CommandContext(ctx, goBinary, "version") //nolint:gosec
 
 	output, err := cmd.Output()
 	if err != nil {
-		return "", fmt.Errorf("failed to run 'go version': %w", err)
+		return "", goVersionError(goBinary, err)
 	}
 
 	versionOutput := strings.TrimSpace(string(output))
@@ -177,3 +519,232 @@ func getInstalledVersionCore(installDir string) (string, error) {
 
 	return "", fmt.Errorf("unable to parse version from output: %s: %w", versionOutput, errVersionParseError)
 }
+
+// CheckPlatform reads the default GOOS and GOARCH of the toolchain in installDir with 'go env' and
+// compares them to the host's. It returns the toolchain's platform, such as "linux/amd64", or an error
+// wrapping ErrPlatformMismatch that names both platforms, which happens when an archive for another
+// platform was installed, e.g. from a mirror set with GO_UPDATER_BASE_URL.
+func CheckPlatform(installDir string) (string, error) {
+	return checkPlatform(context.Background(), installDir)
+}
+
+// checkPlatform implements CheckPlatform, killing 'go env' once ctx is done.
+func checkPlatform(ctx context.Context, installDir string) (string, error) {
+	goBinary := filepath.Join(installDir, "bin", "go")
+
+	// GOOS and GOARCH are cleared and GOENV is disabled so that cross-compilation settings
+	// in the environment or in go env -w do not hide the toolchain's own platform.
+	cmd := exec.CommandContext(ctx, goBinary, "env", "GOOS", "GOARCH") //nolint:gosec
+	cmd.Env = append(os.Environ(), "GOTOOLCHAIN=local", "GOOS=", "GOARCH=", "GOENV=off")
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run 'go env GOOS GOARCH': %w", err)
+	}
+
+	fields := strings.Fields(string(output))
+	if len(fields) != 2 || strings.ContainsAny(fields[0]+fields[1], "/\\") {
+		return "", fmt.Errorf("unable to parse 'go env GOOS GOARCH' output: %q: %w", output, errVersionParseError)
+	}
+
+	platform := fields[0] + "/" + fields[1]
+	host := runtime.GOOS + "/" + runtime.GOARCH
+
+	if platform != host {
+		return "", fmt.Errorf("the Go toolchain in %s is for %s but this host is %s: %w",
+			installDir, platform, host, ErrPlatformMismatch)
+	}
+
+	return platform, nil
+}
+
+// goVersionError describes a failure to run 'go version' with goBinary.
+// A binary the kernel refuses to execute is reported as ErrArchitectureMismatch.
+func goVersionError(goBinary string, err error) error {
+	if errors.Is(err, syscall.ENOEXEC) {
+		return fmt.Errorf("%s is not executable on %s/%s; the archive may be for another platform: %w",
+			goBinary, runtime.GOOS, runtime.GOARCH, ErrArchitectureMismatch)
+	}
+
+	return fmt.Errorf("failed to run 'go version': %w", err)
+}
+
+// ReadVersionFile reads the Go version from the VERSION file at the root of a Go distribution.
+// The first line holds the version, e.g. "go1.21.0"; later lines carry build metadata.
+// Development builds, whose first line starts with "devel", are reported by their revision,
+// e.g. "devel go1.23-abc123", without the build date. Unlike GetInstalledVersion, it does not run
+// the go binary, so it also names the release of a partially extracted tree without bin/go.
+func ReadVersionFile(installDir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(installDir, "VERSION")) //nolint:gosec
+	if err != nil {
+		return "", fmt.Errorf("failed to read VERSION file: %w", err)
+	}
+
+	line, _, _ := strings.Cut(string(data), "\n")
+	fields := strings.Fields(line)
+
+	switch {
+	case len(fields) == 1 && strings.HasPrefix(fields[0], "go"):
+		return fields[0], nil
+	case len(fields) >= 2 && fields[0] == develPrefix && strings.HasPrefix(fields[1], "go"):
+		return develPrefix + " " + fields[1], nil
+	default:
+		return "", fmt.Errorf("unable to parse VERSION file: %q: %w", strings.TrimSpace(line), errVersionParseError)
+	}
+}
+
+// runCheck executes a single deep verification check and records its outcome.
+func runCheck(name string, check func() (string, error)) CheckResult {
+	detail, err := check()
+	if err != nil {
+		logger.Debugf("Deep check %q failed: %v", name, err)
+
+		return CheckResult{Name: name, Passed: false, Detail: err.Error()}
+	}
+
+	logger.Debugf("Deep check %q passed: %s", name, detail)
+
+	return CheckResult{Name: name, Passed: true, Detail: detail}
+}
+
+// runGo runs the go binary with the given arguments and returns its trimmed output.
+// GOTOOLCHAIN is pinned to local so the check exercises the installed toolchain only.
+func runGo(goBinary, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(context.Background(), goBinary, args...) //nolint:gosec
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOTOOLCHAIN=local")
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to run 'go %s': %w: %s",
+			strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// checkBuildInfo runs 'go version -m' against the go binary itself.
+func checkBuildInfo(goBinary string) (string, error) {
+	output, err := runGo(goBinary, "", "version", "-m", goBinary)
+	if err != nil {
+		return "", err
+	}
+
+	firstLine, _, _ := strings.Cut(output, "\n")
+
+	return firstLine, nil
+}
+
+// checkToolchain confirms the compiler, assembler, and linker exist in GOTOOLDIR.
+func checkToolchain(goBinary string) (string, error) {
+	toolDir, err := runGo(goBinary, "", "env", "GOTOOLDIR")
+	if err != nil {
+		return "", err
+	}
+
+	for _, tool := range requiredTools {
+		if runtime.GOOS == "windows" {
+			tool += ".exe"
+		}
+
+		_, err = os.Stat(filepath.Join(toolDir, tool))
+		if err != nil {
+			return "", fmt.Errorf("%w: %s in %s", errToolMissing, tool, toolDir)
+		}
+	}
+
+	return toolDir, nil
+}
+
+// checkCompile builds a trivial program in a temporary directory.
+func checkCompile(goBinary string) (string, error) {
+	tempDir, err := os.MkdirTemp("", "goUpdater-deepcheck-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	source := filepath.Join(tempDir, "main.go")
+
+	err = os.WriteFile(source, []byte("package main\n\nfunc main() {}\n"), testProgramPerm)
+	if err != nil {
+		return "", fmt.Errorf("failed to write test program: %w", err)
+	}
+
+	_, err = runGo(goBinary, tempDir, "build", "-o", filepath.Join(tempDir, "main"), "main.go")
+	if err != nil {
+		return "", err
+	}
+
+	return "trivial program compiled", nil
+}
+
+// VerifySignature verifies the detached OpenPGP signature at sigPath for the archive at archivePath,
+// using the public key at pubkeyPath. The signature and key may be ASCII-armored or binary.
+// It returns an error wrapping ErrSignatureInvalid when the signature does not match.
+func VerifySignature(archivePath, sigPath, pubkeyPath string) error {
+	logger.Debugf("Verifying signature %s for %s with key %s", sigPath, archivePath, pubkeyPath)
+
+	keyring, err := readKeyRing(pubkeyPath)
+	if err != nil {
+		return err
+	}
+
+	signature, err := os.ReadFile(filepath.Clean(sigPath))
+	if err != nil {
+		return fmt.Errorf("failed to read signature: %w", err)
+	}
+
+	archive, err := os.Open(filepath.Clean(archivePath))
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+
+	defer func() { _ = archive.Close() }()
+
+	var signer *openpgp.Entity
+
+	if isArmored(signature) {
+		signer, err = openpgp.CheckArmoredDetachedSignature(keyring, archive, bytes.NewReader(signature), nil)
+	} else {
+		signer, err = openpgp.CheckDetachedSignature(keyring, archive, bytes.NewReader(signature), nil)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSignatureInvalid, err)
+	}
+
+	for name := range signer.Identities {
+		logger.Debugf("Archive signed by %s", name)
+	}
+
+	return nil
+}
+
+// readKeyRing reads an ASCII-armored or binary OpenPGP public key ring.
+func readKeyRing(pubkeyPath string) (openpgp.EntityList, error) {
+	data, err := os.ReadFile(filepath.Clean(pubkeyPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+
+	var keyring openpgp.EntityList
+
+	if isArmored(data) {
+		keyring, err = openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	} else {
+		keyring, err = openpgp.ReadKeyRing(bytes.NewReader(data))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	return keyring, nil
+}
+
+// isArmored reports whether data is ASCII-armored OpenPGP data.
+func isArmored(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("-----BEGIN PGP"))
+}
@@ -4,9 +4,19 @@
 package verify
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
 )
 
 func createTestGoBinary(t *testing.T, script string) string {
@@ -108,12 +118,30 @@ func TestVerifyInstallation(t *testing.T) {
 			expectedVersion: "go1.21.0",
 			wantErr:         true,
 		},
+		{
+			name:            "patch component omitted",
+			script:          "#!/bin/bash\necho \"go version go1.21 linux/amd64\"",
+			expectedVersion: "go1.21.0",
+			wantErr:         false,
+		},
 		{
 			name:            "version mismatch",
 			script:          "#!/bin/bash\necho \"go version go1.20.0 linux/amd64\"",
 			expectedVersion: "go1.21.0",
 			wantErr:         true,
 		},
+		{
+			name:            "expected version is a prefix of the installed one",
+			script:          "#!/bin/bash\necho \"go version go1.21.10 linux/amd64\"",
+			expectedVersion: "1.21.1",
+			wantErr:         true,
+		},
+		{
+			name:            "expected version without prefix",
+			script:          "#!/bin/bash\necho \"go version go1.21.1 linux/amd64\"",
+			expectedVersion: "1.21.1",
+			wantErr:         false,
+		},
 		{
 			name:            "command fails",
 			script:          "#!/bin/bash\nexit 1",
@@ -141,6 +169,18 @@ func TestVerifyInstallation(t *testing.T) {
 	}
 }
 
+func TestVerifyInstallationArchitectureMismatch(t *testing.T) {
+	t.Parallel()
+
+	// A file without an interpreter line or a valid executable header cannot be executed.
+	installDir := createTestGoBinary(t, "\x7fELF\x02\x01\x01\x00not a binary for this host")
+
+	err := Installation(installDir, "go1.21.0")
+	if !errors.Is(err, ErrArchitectureMismatch) {
+		t.Errorf("Installation() error = %v, want %v", err, ErrArchitectureMismatch)
+	}
+}
+
 func TestGetInstalledVersion(t *testing.T) {
 	t.Parallel()
 	runGetInstalledVersionTests(t, GetInstalledVersion, "GetInstalledVersion")
@@ -320,7 +360,43 @@ func getGetInstalledVersionCoreTestCases() []struct {
 			want:    "",
 			wantErr: true,
 		},
+		{
+			name:       "VERSION file without binary",
+			installDir: "",
+			setup: func(t *testing.T) string {
+				t.Helper()
+
+				return createVersionFile(t, t.TempDir(), "go1.22.3\ntime 2024-05-01T19:53:55Z\n")
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name:       "binary preferred over VERSION file",
+			installDir: "",
+			setup: func(t *testing.T) string {
+				t.Helper()
+
+				installDir := createTestGoBinary(t, "#!/bin/bash\necho \"go version go1.21.0 linux/amd64\"")
+
+				return createVersionFile(t, installDir, "go1.22.3\n")
+			},
+			want:    "go1.21.0",
+			wantErr: false,
+		},
+	}
+}
+
+// createVersionFile writes a VERSION file with the given content into installDir and returns installDir.
+func createVersionFile(t *testing.T, installDir, content string) string {
+	t.Helper()
+
+	err := os.WriteFile(filepath.Join(installDir, "VERSION"), []byte(content), 0600)
+	if err != nil {
+		t.Fatal(err)
 	}
+
+	return installDir
 }
 
 func TestGetInstalledVersionCore(t *testing.T) {
@@ -337,7 +413,7 @@ func TestGetInstalledVersionCore(t *testing.T) {
 				installDir = testCase.installDir
 			}
 
-			got, err := getInstalledVersionCore(installDir)
+			got, err := getInstalledVersionCore(context.Background(), installDir)
 			if testCase.wantErr {
 				if err == nil {
 					t.Error("expected error")
@@ -356,3 +432,403 @@ func TestGetInstalledVersionCore(t *testing.T) {
 		})
 	}
 }
+
+func TestReadVersionFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr error
+	}{
+		{name: "release", content: "go1.22.3\ntime 2024-05-01T19:53:55Z\n", want: "go1.22.3", wantErr: nil},
+		{
+			name:    "development build",
+			content: "devel go1.23-abc123 Mon Jan 1 00:00:00 2024 +0000\n",
+			want:    "devel go1.23-abc123",
+			wantErr: nil,
+		},
+		{name: "no go prefix", content: "1.22.3\n", want: "", wantErr: errVersionParseError},
+		{name: "trailing text", content: "go1.22.3 extra\n", want: "", wantErr: errVersionParseError},
+		{name: "empty", content: "", want: "", wantErr: errVersionParseError},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ReadVersionFile(createVersionFile(t, t.TempDir(), testCase.content))
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("ReadVersionFile() error = %v, want %v", err, testCase.wantErr)
+			}
+
+			if got != testCase.want {
+				t.Errorf("ReadVersionFile() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+// deepCheckScript emulates the go subcommands used by DeepCheck for a toolchain targeting goos/goarch.
+// The tool directory and its binaries are created by the caller.
+func deepCheckScript(goos, goarch string) string {
+	return fmt.Sprintf(`#!/bin/bash
+case "$1" in
+  version)
+    if [ "$2" = "-m" ]; then echo "$3: go1.21.0"; else echo "go version go1.21.0 %[1]s/%[2]s"; fi ;;
+  env)
+    if [ "$2" = "GOOS" ]; then printf '%%s\n%%s\n' %[1]s %[2]s; else echo "$(dirname "$0")/../pkg/tool/linux_amd64"; fi ;;
+  build) exit 0 ;;
+  *) exit 1 ;;
+esac
+`, goos, goarch)
+}
+
+func TestDeepCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		createTool bool
+		wantFailed []string
+	}{
+		{
+			name:       "all checks pass",
+			createTool: true,
+			wantFailed: nil,
+		},
+		{
+			name:       "missing toolchain binaries",
+			createTool: false,
+			wantFailed: []string{"toolchain"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			installDir := createTestGoBinary(t, deepCheckScript(runtime.GOOS, runtime.GOARCH))
+			createTestLayout(t, installDir)
+
+			if testCase.createTool {
+				toolDir := filepath.Join(installDir, "pkg", "tool", "linux_amd64")
+
+				err := os.MkdirAll(toolDir, 0750)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for _, tool := range requiredTools {
+					err = os.WriteFile(filepath.Join(toolDir, tool), nil, 0600)
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			results, err := DeepCheck(installDir)
+			if (err != nil) != (len(testCase.wantFailed) > 0) {
+				t.Fatalf("DeepCheck() error = %v, wantFailed %v", err, testCase.wantFailed)
+			}
+
+			if len(results) != 6 {
+				t.Fatalf("DeepCheck() returned %d results, want 6", len(results))
+			}
+
+			var failed []string
+
+			for _, result := range results {
+				if !result.Passed {
+					failed = append(failed, result.Name)
+				}
+			}
+
+			if strings.Join(failed, ",") != strings.Join(testCase.wantFailed, ",") {
+				t.Errorf("failed checks = %v, want %v", failed, testCase.wantFailed)
+			}
+		})
+	}
+}
+
+func TestVerifyLayout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		remove      string
+		wantMissing string
+	}{
+		{name: "complete", remove: "", wantMissing: ""},
+		{name: "missing src", remove: "src", wantMissing: "src"},
+		{name: "missing VERSION", remove: "VERSION", wantMissing: "VERSION"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			installDir := createTestGoBinary(t, "#!/bin/sh\n")
+			createTestLayout(t, installDir)
+
+			if testCase.remove != "" {
+				err := os.RemoveAll(filepath.Join(installDir, testCase.remove))
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := VerifyLayout(installDir)
+			if testCase.wantMissing == "" {
+				if err != nil {
+					t.Errorf("VerifyLayout() error = %v, want nil", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, ErrIncompleteInstallation) || !strings.Contains(err.Error(), testCase.wantMissing) {
+				t.Errorf("VerifyLayout() error = %v, want %v naming %s", err, ErrIncompleteInstallation, testCase.wantMissing)
+			}
+		})
+	}
+}
+
+// createTestLayout adds the entries VerifyLayout requires, other than bin/go, to installDir.
+func createTestLayout(t *testing.T, installDir string) {
+	t.Helper()
+
+	for _, dir := range []string{"pkg", "src"} {
+		err := os.MkdirAll(filepath.Join(installDir, dir), 0750)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gofmt := filepath.Join(installDir, "bin", "gofmt")
+	if runtime.GOOS == "windows" {
+		gofmt += ".exe"
+	}
+
+	for _, file := range []string{gofmt, filepath.Join(installDir, "VERSION")} {
+		err := os.WriteFile(file, []byte("go1.21.0\n"), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCheckPlatform(t *testing.T) {
+	t.Parallel()
+
+	otherArch := "arm64"
+	if runtime.GOARCH == otherArch {
+		otherArch = "amd64"
+	}
+
+	tests := []struct {
+		name    string
+		script  string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "host platform",
+			script:  deepCheckScript(runtime.GOOS, runtime.GOARCH),
+			want:    runtime.GOOS + "/" + runtime.GOARCH,
+			wantErr: nil,
+		},
+		{
+			name:    "other architecture",
+			script:  deepCheckScript(runtime.GOOS, otherArch),
+			want:    "",
+			wantErr: ErrPlatformMismatch,
+		},
+		{
+			name:    "unparseable output",
+			script:  "#!/bin/bash\necho \"go version go1.21.0 linux/amd64\"",
+			want:    "",
+			wantErr: errVersionParseError,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			platform, err := CheckPlatform(createTestGoBinary(t, testCase.script))
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("CheckPlatform() error = %v, want %v", err, testCase.wantErr)
+			}
+
+			if platform != testCase.want {
+				t.Errorf("CheckPlatform() = %q, want %q", platform, testCase.want)
+			}
+
+			if errors.Is(testCase.wantErr, ErrPlatformMismatch) && !strings.Contains(err.Error(), otherArch) {
+				t.Errorf("CheckPlatform() error %q does not name the toolchain's platform", err)
+			}
+		})
+	}
+}
+
+func TestGetAllVerificationInfo(t *testing.T) {
+	t.Parallel()
+
+	validDir := createTestGoBinary(t, "#!/bin/bash\necho \"go version go1.21.0 linux/amd64\"")
+	brokenDir := createTestGoBinary(t, "#!/bin/bash\nexit 1")
+	missingDir := filepath.Join(t.TempDir(), "missing")
+
+	installDirs := []string{validDir, brokenDir, missingDir}
+
+	results := GetAllVerificationInfo(installDirs)
+	if len(results) != len(installDirs) {
+		t.Fatalf("GetAllVerificationInfo() returned %d results, want %d", len(results), len(installDirs))
+	}
+
+	wantStatus := []string{"verified", "failed", "failed"}
+
+	for index, info := range results {
+		if info.InstallDir != installDirs[index] {
+			t.Errorf("results[%d].InstallDir = %s, want %s", index, info.InstallDir, installDirs[index])
+		}
+
+		if info.Status != wantStatus[index] {
+			t.Errorf("results[%d].Status = %s, want %s", index, info.Status, wantStatus[index])
+		}
+	}
+}
+
+// TestWorkerCount is not parallel because SetWorkers changes package-level state.
+func TestWorkerCount(t *testing.T) {
+	t.Cleanup(func() { SetWorkers(0) })
+
+	SetWorkers(2)
+
+	if got := workerCount(5); got != 2 {
+		t.Errorf("workerCount(5) with 2 workers = %d, want 2", got)
+	}
+
+	if got := workerCount(1); got != 1 {
+		t.Errorf("workerCount(1) with 2 workers = %d, want 1", got)
+	}
+
+	SetWorkers(0)
+
+	if got := workerCount(1024); got != min(runtime.NumCPU(), 1024) {
+		t.Errorf("workerCount(1024) by default = %d, want %d", got, runtime.NumCPU())
+	}
+}
+
+func TestDiscoverInstallations(t *testing.T) {
+	t.Parallel()
+
+	primaryDir := t.TempDir()
+
+	installDirs := DiscoverInstallations(primaryDir + string(filepath.Separator))
+	if len(installDirs) == 0 || installDirs[0] != primaryDir {
+		t.Errorf("DiscoverInstallations() = %v, want first entry %s", installDirs, primaryDir)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	t.Parallel()
+
+	config := &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA}
+
+	signer, err := openpgp.NewEntity("Go Release", "", "release@example.com", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := openpgp.NewEntity("Someone Else", "", "other@example.com", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	content := []byte("go archive content")
+	archivePath := filepath.Join(dir, "go1.21.0.linux-amd64.tar.gz")
+	sigPath := archivePath + ".asc"
+
+	err = os.WriteFile(archivePath, content, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var signature bytes.Buffer
+
+	err = openpgp.ArmoredDetachSign(&signature, signer, bytes.NewReader(content), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(sigPath, signature.Bytes(), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeKey := func(t *testing.T, entity *openpgp.Entity) string {
+		t.Helper()
+
+		var key bytes.Buffer
+
+		writer, err := armor.Encode(&key, openpgp.PublicKeyType, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = entity.Serialize(writer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_ = writer.Close()
+
+		keyPath := filepath.Join(t.TempDir(), "key.asc")
+
+		err = os.WriteFile(keyPath, key.Bytes(), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return keyPath
+	}
+
+	tamperedPath := filepath.Join(dir, "tampered.tar.gz")
+
+	err = os.WriteFile(tamperedPath, []byte("tampered content"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		archivePath string
+		sigPath     string
+		keyPath     string
+		wantInvalid bool
+		wantErr     bool
+	}{
+		{name: "valid signature", archivePath: archivePath, sigPath: sigPath, keyPath: writeKey(t, signer), wantInvalid: false, wantErr: false},
+		{name: "tampered archive", archivePath: tamperedPath, sigPath: sigPath, keyPath: writeKey(t, signer), wantInvalid: true, wantErr: true},
+		{name: "wrong key", archivePath: archivePath, sigPath: sigPath, keyPath: writeKey(t, other), wantInvalid: true, wantErr: true},
+		{name: "missing signature", archivePath: archivePath, sigPath: sigPath + ".missing", keyPath: writeKey(t, signer), wantInvalid: false, wantErr: true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := VerifySignature(testCase.archivePath, testCase.sigPath, testCase.keyPath)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("VerifySignature() error = %v, wantErr %v", err, testCase.wantErr)
+			}
+
+			if errors.Is(err, ErrSignatureInvalid) != testCase.wantInvalid {
+				t.Errorf("errors.Is(err, ErrSignatureInvalid) = %v, want %v", !testCase.wantInvalid, testCase.wantInvalid)
+			}
+		})
+	}
+}
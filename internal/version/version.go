@@ -13,10 +13,10 @@ import (
 	"fmt"
 	"os"
 	"runtime/debug"
-	"strconv"
-	"strings"
 	"sync"
 	"time"
+
+	"github.com/nicholas-fedor/goUpdater/internal/download"
 )
 
 // versionMutex protects access to global version variables.
@@ -25,6 +25,9 @@ var versionMutex sync.Mutex //nolint:gochecknoglobals
 // versionOnce ensures debug.ReadBuildInfo is only called once.
 var versionOnce sync.Once //nolint:gochecknoglobals
 
+// buildInfo holds the values read from debug.ReadBuildInfo, used for fields not set via ldflags.
+var buildInfo Info //nolint:gochecknoglobals
+
 // version is set at build time using ldflags.
 var version string
 
@@ -164,7 +167,7 @@ func GetVersion(format string, jsonFlag, shortFlag, verboseFlag bool) {
 
 // getInfo returns the version information, initializing it if necessary.
 // This function ensures thread-safe initialization of build information.
-func getInfo() Info { //nolint:gocognit,cyclop
+func getInfo() Info {
 	versionMutex.Lock()
 
 	info := Info{
@@ -177,33 +180,60 @@ func getInfo() Info { //nolint:gocognit,cyclop
 
 	versionMutex.Unlock()
 
-	// If version is not set via ldflags, default to "dev"
+	versionOnce.Do(func() { buildInfo = readBuildInfo() })
+
+	if info.version == "" {
+		info.version = buildInfo.version
+	}
+
+	if info.commit == "" {
+		info.commit = buildInfo.commit
+	}
+
+	if info.date == "" {
+		info.date = buildInfo.date
+	}
+
+	if info.goVersion == "" {
+		info.goVersion = buildInfo.goVersion
+	}
+
+	// If version is not set via ldflags or by go install, default to "dev"
 	if info.version == "" {
 		info.version = "dev"
 	}
 
-	versionOnce.Do(func() {
-		// Only use debug.ReadBuildInfo if commit/date are not set via ldflags
-		if info.commit == "" || info.date == "" { //nolint:nestif
-			if bi, ok := debug.ReadBuildInfo(); ok {
-				for _, setting := range bi.Settings {
-					switch setting.Key {
-					case "vcs.revision":
-						if info.commit == "" {
-							info.commit = setting.Value
-						}
-					case "vcs.time":
-						if info.date == "" {
-							t, err := time.Parse(time.RFC3339, setting.Value)
-							if err == nil {
-								info.date = t.Format(time.RFC3339)
-							}
-						}
-					}
-				}
+	return info
+}
+
+// readBuildInfo returns the module version, VCS revision and time, and Go version recorded in the binary.
+// The module version is only set for binaries built with 'go install module@version'; source builds
+// report "(devel)", which is ignored.
+func readBuildInfo() Info {
+	var info Info
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	if bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		info.version = bi.Main.Version
+	}
+
+	info.goVersion = bi.GoVersion
+
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.commit = setting.Value
+		case "vcs.time":
+			t, err := time.Parse(time.RFC3339, setting.Value)
+			if err == nil {
+				info.date = t.Format(time.RFC3339)
 			}
 		}
-	})
+	}
 
 	return info
 }
@@ -318,54 +348,10 @@ func displayVerbose(info VersionInfo) {
 
 // Compare compares two Go version strings.
 // Returns -1 if v1 < v2, 0 if v1 == v2, 1 if v1 > v2.
-// This function handles semantic versioning for Go versions correctly,
-// treating missing version parts as 0 (e.g., "1.21" is equivalent to "1.21.0").
+// It delegates to download.CompareGoVersions, so missing parts count as 0 ("1.21" equals "1.21.0"),
+// the "go" prefix is optional, and pre-releases sort below the final release.
 func Compare(v1, v2 string) int {
-	parts1 := strings.Split(v1, ".")
-	parts2 := strings.Split(v2, ".")
-
-	maxLen := max(len(parts2), len(parts1))
-
-	for len(parts1) < maxLen {
-		parts1 = append(parts1, "0")
-	}
-
-	for len(parts2) < maxLen {
-		parts2 = append(parts2, "0")
-	}
-
-	for index := range maxLen {
-		if cmp := compareVersionParts(parts1[index], parts2[index]); cmp != 0 {
-			return cmp
-		}
-	}
-
-	return 0
-}
-
-// compareVersionParts compares two version parts.
-// Returns -1 if part1 < part2, 0 if part1 == part2, 1 if part1 > part2.
-// Handles both numeric and string comparisons, with numeric taking precedence.
-func compareVersionParts(part1, part2 string) int {
-	num1, err1 := strconv.Atoi(part1)
-	num2, err2 := strconv.Atoi(part2)
-
-	switch {
-	case err1 != nil || err2 != nil:
-		if part1 < part2 {
-			return -1
-		} else if part1 > part2 {
-			return 1
-		}
-
-		return 0
-	case num1 < num2:
-		return -1
-	case num1 > num2:
-		return 1
-	default:
-		return 0
-	}
+	return download.CompareGoVersions(v1, v2)
 }
 
 // displayJSON displays version information in JSON format.
@@ -5,6 +5,7 @@ package version
 
 import (
 	"os"
+	"runtime"
 	"strings"
 	"testing"
 )
@@ -60,7 +61,7 @@ func TestGetVersionInfo(t *testing.T) {
 				Version:   "dev",
 				Commit:    "def456",
 				Date:      "2023-10-02T13:00:00Z",
-				GoVersion: "",
+				GoVersion: runtime.Version(),
 				Platform:  "",
 			},
 		},
@@ -90,7 +91,7 @@ func TestGetVersionInfo(t *testing.T) {
 				Version:   "1.0.0",
 				Commit:    "",
 				Date:      "invalid-date",
-				GoVersion: "",
+				GoVersion: runtime.Version(),
 				Platform:  "",
 			},
 		},
@@ -128,6 +129,20 @@ func TestGetVersionInfoWithDebugFallback(t *testing.T) {
 	// or more complex mocking, which is beyond basic unit test scope
 }
 
+func TestReadBuildInfo(t *testing.T) {
+	t.Parallel()
+
+	info := readBuildInfo()
+
+	if info.goVersion != runtime.Version() {
+		t.Errorf("readBuildInfo() Go version = %q, want %q", info.goVersion, runtime.Version())
+	}
+
+	if info.version == "(devel)" {
+		t.Error("readBuildInfo() reported the (devel) placeholder as the version")
+	}
+}
+
 // TestSetterFunctions tests all setter functions.
 func TestSetterFunctions(t *testing.T) {
 	t.Parallel()
@@ -459,6 +474,18 @@ func getAdvancedCompareTestCases() []struct {
 			v2:       "1.21.0.1",
 			expected: -1,
 		},
+		{
+			name:     "go prefix on one side",
+			v1:       "go1.22.0",
+			v2:       "1.21.0",
+			expected: 1,
+		},
+		{
+			name:     "release candidate before final",
+			v1:       "go1.24rc1",
+			v2:       "go1.24.0",
+			expected: -1,
+		},
 	}
 }
 